@@ -0,0 +1,89 @@
+package ansistrings
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Adaptive is pair of ANSIStyle for light and dark background.
+// It is resolved by LightBackground of Profile at render time.
+type Adaptive struct {
+	Light ANSIStyle
+	Dark  ANSIStyle
+}
+
+// Resolve returns ANSIStyle for background of given Profile
+func (a Adaptive) Resolve(p Profile) ANSIStyle {
+	if p.LightBackground {
+		return a.Light
+	}
+	return a.Dark
+}
+
+// Adaptive sets Adaptive style which is resolved at render time.
+// colors set explicitly take precedence over colors of Adaptive.
+func (s *ANSIStrings) Adaptive(a Adaptive) *ANSIStrings {
+	s.CurrentStr().Adaptive(a)
+	return s
+}
+
+// Adaptive sets Adaptive style which is resolved at render time.
+// colors set explicitly take precedence over colors of Adaptive.
+func (s *ANSIStyle) Adaptive(a Adaptive) *ANSIStyle {
+	s.adaptive = &a
+	return s
+}
+
+// resolveAdaptive applies colors which are not set and attributes of resolved style. link and other attributes are kept.
+func (s *ANSIString) resolveAdaptive() {
+	a := s.adaptive.Resolve(s.currentProfile())
+	s.adaptive = nil
+	if !s.color.isSet && !s.colorN.isSet && !s.rgb.isSet {
+		s.color, s.colorN, s.rgb = a.color, a.colorN, a.rgb
+	}
+	if !s.bgColor.isSet && !s.bgColorN.isSet && !s.bgRgb.isSet {
+		s.bgColor, s.bgColorN, s.bgRgb = a.bgColor, a.bgColorN, a.bgRgb
+	}
+	s.withBold = s.withBold || a.withBold
+	s.withDelete = s.withDelete || a.withDelete
+	s.withItalic = s.withItalic || a.withItalic
+	s.withBlink = s.withBlink || a.withBlink
+	s.withRapidBlink = s.withRapidBlink || a.withRapidBlink
+	s.withFaint = s.withFaint || a.withFaint
+	s.withConceal = s.withConceal || a.withConceal
+	s.withInverted = s.withInverted || a.withInverted
+	s.withUnderLine = s.withUnderLine || a.withUnderLine
+	if a.font != 0 {
+		s.font = a.font
+	}
+}
+
+// DetectLightBackground detects whether background of terminal is light.
+// Background color is asked by OSC 11 at first and then COLORFGBG is used.
+// It returns false if it cannot be detected because most terminals are dark.
+func DetectLightBackground(w io.Writer, rr *ResponseReader, timeout time.Duration) bool {
+	if rr != nil {
+		if res, err := rr.Query(w, QueryBackground, timeout); err == nil && res.Kind == ResponseBackground {
+			return !IsDark(res.Color)
+		}
+	}
+	light, _ := lightBackgroundFromEnv(os.Getenv)
+	return light
+}
+
+// lightBackgroundFromEnv detects background from COLORFGBG like "15;0"
+func lightBackgroundFromEnv(getenv func(string) string) (light bool, ok bool) {
+	v := getenv("COLORFGBG")
+	if v == "" {
+		return false, false
+	}
+	parts := strings.Split(v, ";")
+	bg, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return false, false
+	}
+	return bg == 7 || (bg >= 9 && bg <= 15), true
+}
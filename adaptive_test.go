@@ -0,0 +1,77 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestAdaptive(t *testing.T) {
+	light := s.NewANSIStyle()
+	light.Blue()
+	dark := s.NewANSIStyle()
+	dark.LightCyan().Bold()
+	title := s.Adaptive{Light: light, Dark: dark}
+
+	v := s.NewANSIStrings()
+	v.Str("title").Adaptive(title)
+	v.SetProfile(s.Profile{LightBackground: true})
+	a := "\033[34mtitle\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+	v.SetProfile(s.Profile{})
+	a = "\033[96m\033[1mtitle\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	// link and chained attributes are kept
+	v = s.NewANSIStrings()
+	v.Str("x").Link("https://example.com/").Adaptive(title).UnderLine()
+	v.SetProfile(s.Profile{LightBackground: true})
+	a = "\033[34m\033[4mx (https://example.com/)\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	// explicit color takes precedence
+	v = s.NewANSIStrings()
+	v.SetProfile(s.Profile{LightBackground: true})
+	v.Str("x").Adaptive(title).Red().Str("y").BgRGB(1, 2, 3).Adaptive(title)
+	a = "\033[31mx\033[0m\033[34m\033[48;2;1;2;3my\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	// ANSIStyle can be adaptive
+	style := s.NewANSIStyle()
+	style.Adaptive(title)
+	v = s.NewANSIStrings()
+	v.SetProfile(s.Profile{LightBackground: true})
+	v.Str("x").Style(style)
+	a = "\033[34mx\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+}
+
+func TestDetectLightBackground(t *testing.T) {
+	rr := s.NewResponseReader(bytes.NewBufferString("\033]11;rgb:fdfd/f6f6/e3e3\033\\"))
+	if !s.DetectLightBackground(&bytes.Buffer{}, rr, time.Second) {
+		t.Error("Solarized Light background should be light")
+	}
+
+	defer os.Setenv("COLORFGBG", os.Getenv("COLORFGBG"))
+	os.Setenv("COLORFGBG", "0;15")
+	if !s.DetectLightBackground(nil, nil, 0) {
+		t.Error("COLORFGBG=0;15 should be light")
+	}
+	os.Setenv("COLORFGBG", "15;0")
+	if s.DetectLightBackground(nil, nil, 0) {
+		t.Error("COLORFGBG=15;0 should be dark")
+	}
+}
@@ -4,7 +4,8 @@ package ansistrings
 import (
 	"errors"
 	"fmt"
-	"regexp"
+	"io"
+	"os"
 	"time"
 )
 
@@ -40,6 +41,39 @@ const (
 	_down        = "B"
 	_forward     = "C"
 	_back        = "D"
+	_nextLine    = "E"
+	_prevLine    = "F"
+	_column      = "G"
+	_insertLine  = "L"
+	_deleteLine  = "M"
+	_insertChar  = "@"
+	_deleteChar  = "P"
+	_eraseChar   = "X"
+	_scrollUp    = "S"
+	_scrollDown  = "T"
+)
+
+const (
+	_hideCursor     = "\033[?25l"
+	_showCursor     = "\033[?25h"
+	_enterAltScreen = "\033[?1049h"
+	_leaveAltScreen = "\033[?1049l"
+	_beginSync      = "\033[?2026h"
+	_endSync        = "\033[?2026l"
+)
+
+// CursorShape is shape of cursor
+type CursorShape int
+
+// constant value of cursor shapes
+const (
+	CursorDefault CursorShape = iota
+	CursorBlinkingBlock
+	CursorBlock
+	CursorBlinkingUnderline
+	CursorUnderline
+	CursorBlinkingBar
+	CursorBar
 )
 
 var name2color = map[string]int{
@@ -101,8 +135,13 @@ type ANSIStyle struct {
 	withInverted   bool
 	withUnderLine  bool
 	font           int
-	sleep          time.Duration
-	skipSleep      bool
+	link           struct {
+		url    string
+		params string
+	}
+	sleep     time.Duration
+	skipSleep bool
+	adaptive  *Adaptive
 }
 
 // ANSIString is struct which contains string with ANSI escaping setting
@@ -117,13 +156,20 @@ type ANSIString struct {
 		y int
 	}
 	clear bool
+	// escape is control sequence which is output as it is
+	escape string
+	// passthrough is whether escape should be passed through terminal multiplexer
+	passthrough bool
+	profile     *Profile
 	ANSIStyle
 }
 
 // ANSIStrings is struct which contains ANSIString
 type ANSIStrings struct {
-	strings []ANSIString
-	index   int
+	strings  []ANSIString
+	index    int
+	sanitize SanitizePolicy
+	profile  *Profile
 }
 
 // NewANSIStrings returns new ANSIStrings
@@ -154,13 +200,32 @@ func ColorNumFromName(color string) (cn int, e error) {
 
 // Print prints ANSI escaped strings and disard them
 func (s *ANSIStrings) Print() *ANSIStrings {
-	str := ""
+	return s.Fprint(os.Stdout)
+}
+
+// Fprint prints ANSI escaped strings to w and disard them.
+// Output between Pauses is wrapped by synchronized update if Profile.SyncOutput is true.
+func (s *ANSIStrings) Fprint(w io.Writer) *ANSIStrings {
+	sync := s.currentProfile().SyncOutput
+	buf := ""
+	flush := func() {
+		if buf == "" {
+			return
+		}
+		if sync {
+			buf = _beginSync + buf + _endSync
+		}
+		fmt.Fprint(w, buf)
+		buf = ""
+	}
 	for _, as := range s.strings {
-		str = as.String()
-		if str != "" {
-			fmt.Print(str)
+		as.profile = s.profile
+		if as.isPause() {
+			flush()
 		}
+		buf += as.String()
 	}
+	flush()
 	s.strings = make([]ANSIString, 0)
 	return s
 }
@@ -184,7 +249,9 @@ func (s *ANSIStrings) Style(style ANSIStyle) *ANSIStrings {
 	as.withInverted = style.withInverted
 	as.withUnderLine = style.withUnderLine
 	as.font = style.font
+	as.link = style.link
 	as.sleep = style.sleep
+	as.adaptive = style.adaptive
 	return s
 }
 
@@ -195,6 +262,10 @@ func (s *ANSIStrings) Pause(i ...time.Duration) *ANSIStrings {
 	return s
 }
 
+func (s ANSIString) isPause() bool {
+	return s.sleep != 0 && !s.clear && s.position.x == 0 && s.direction.n == 0 && s.escape == ""
+}
+
 // Pause sleeps given millisecond(s)
 func (s *ANSIStyle) Pause(i ...time.Duration) *ANSIStyle {
 	var n time.Duration = 1
@@ -225,6 +296,7 @@ func (s ANSIStrings) String() string {
 	for i := 0; i < len(s.strings); i++ {
 		as := s.strings[i]
 		as.skipSleep = true
+		as.profile = s.profile
 		str += as.String()
 		as.skipSleep = false
 	}
@@ -233,6 +305,9 @@ func (s ANSIStrings) String() string {
 
 // String returns ANSI escaped string
 func (s ANSIString) String() string {
+	if s.adaptive != nil {
+		s.resolveAdaptive()
+	}
 	color := ""
 	if s.clear {
 		color += "\033[2J\033[1;1H"
@@ -240,57 +315,19 @@ func (s ANSIString) String() string {
 		color += fmt.Sprintf("\033[%d;%dH", s.position.y, s.position.x)
 	} else if s.direction.n != 0 {
 		color += fmt.Sprintf("\033[%d%s", s.direction.n, s.direction.direction)
+	} else if s.escape != "" && s.passthrough {
+		color += s.currentProfile().Multiplexer.wrap(s.escape)
+	} else if s.escape != "" {
+		color += s.escape
 	} else if s.sleep != 0 && s.skipSleep == false {
 		time.Sleep(s.sleep)
 	} else {
-		if s.color.isSet {
-			color += fmt.Sprintf("\033[%dm", s.color.color)
-		} else if s.rgb.isSet {
-			color += fmt.Sprintf("\033[38;2;%d;%d;%dm", s.rgb.r, s.rgb.g, s.rgb.b)
-		} else if s.colorN.isSet {
-			color += fmt.Sprintf("\033[38;5;%dm", s.colorN.color)
-		}
-		if s.bgColor.isSet {
-			color += fmt.Sprintf("\033[%dm", s.bgColor.color+10)
-		} else if s.bgRgb.isSet {
-			color += fmt.Sprintf("\033[48;2;%d;%d;%dm", s.bgRgb.r, s.bgRgb.g, s.bgRgb.b)
-		} else if s.bgColorN.isSet {
-			color += fmt.Sprintf("\033[48;5;%dm", s.bgColorN.color)
-		}
-		if s.withBold {
-			color += _bold
-		}
-		if s.withFaint {
-			color += _faint
-		}
-		if s.withItalic {
-			color += _italic
-		}
-		if s.withUnderLine {
-			color += _underLine
-		}
-		if s.withBlink {
-			color += _blink
-		}
-		if s.withRapidBlink {
-			color += _rapidBlink
-		}
-		if s.withInverted {
-			color += _inverted
-		}
-		if s.withConceal {
-			color += _conceal
-		}
-		if s.withDelete {
-			color += _delete
-		}
-		if s.font != 0 {
-			color += fmt.Sprintf("\033[%dm", s.font+10)
-		}
-
+		color = s.ANSIStyle.sequence()
+		str := s.linkedStr()
 		if color != "" {
-			return fmt.Sprintf(color+"%s"+_reset, s.resetWithLineBreak(s.Str, color))
+			return fmt.Sprintf(color+"%s"+_reset, s.resetWithLineBreak(str, color))
 		}
+		return str
 	}
 	// not required reset with position
 	if color != "" {
@@ -299,9 +336,58 @@ func (s ANSIString) String() string {
 	return s.Str
 }
 
+// sequence returns escape sequence to start style
+func (s ANSIStyle) sequence() string {
+	color := ""
+	if s.color.isSet {
+		color += fmt.Sprintf("\033[%dm", s.color.color)
+	} else if s.rgb.isSet {
+		color += fmt.Sprintf("\033[38;2;%d;%d;%dm", s.rgb.r, s.rgb.g, s.rgb.b)
+	} else if s.colorN.isSet {
+		color += fmt.Sprintf("\033[38;5;%dm", s.colorN.color)
+	}
+	if s.bgColor.isSet {
+		color += fmt.Sprintf("\033[%dm", s.bgColor.color+10)
+	} else if s.bgRgb.isSet {
+		color += fmt.Sprintf("\033[48;2;%d;%d;%dm", s.bgRgb.r, s.bgRgb.g, s.bgRgb.b)
+	} else if s.bgColorN.isSet {
+		color += fmt.Sprintf("\033[48;5;%dm", s.bgColorN.color)
+	}
+	if s.withBold {
+		color += _bold
+	}
+	if s.withFaint {
+		color += _faint
+	}
+	if s.withItalic {
+		color += _italic
+	}
+	if s.withUnderLine {
+		color += _underLine
+	}
+	if s.withBlink {
+		color += _blink
+	}
+	if s.withRapidBlink {
+		color += _rapidBlink
+	}
+	if s.withInverted {
+		color += _inverted
+	}
+	if s.withConceal {
+		color += _conceal
+	}
+	if s.withDelete {
+		color += _delete
+	}
+	if s.font != 0 {
+		color += fmt.Sprintf("\033[%dm", s.font+10)
+	}
+	return color
+}
+
 func (s *ANSIString) resetWithLineBreak(str string, color string) string {
-	var r = regexp.MustCompile("(?s)([\r\n]+)")
-	return r.ReplaceAllString(str, _reset+"$1"+color)
+	return lineBreak.ReplaceAllString(str, _reset+"$1"+color)
 }
 
 // BgColor sets background color of string
@@ -750,6 +836,88 @@ func (s *ANSIStrings) Back(n ...int) *ANSIStrings {
 	return s.setDirection(_back, n)
 }
 
+// NextLine moves curosr to beginning of next line
+func (s *ANSIStrings) NextLine(n ...int) *ANSIStrings {
+	return s.setDirection(_nextLine, n)
+}
+
+// PrevLine moves curosr to beginning of previous line
+func (s *ANSIStrings) PrevLine(n ...int) *ANSIStrings {
+	return s.setDirection(_prevLine, n)
+}
+
+// Column moves cursor to given column
+func (s *ANSIStrings) Column(n int) *ANSIStrings {
+	if n < 1 {
+		panic("column should be greater than 0")
+	}
+	return s.setDirection(_column, []int{n})
+}
+
+// SaveCursor saves cursor position(DECSC)
+func (s *ANSIStrings) SaveCursor() *ANSIStrings {
+	return s.setEscape("\0337")
+}
+
+// RestoreCursor restores cursor position saved by SaveCursor(DECRC)
+func (s *ANSIStrings) RestoreCursor() *ANSIStrings {
+	return s.setEscape("\0338")
+}
+
+// SCOSaveCursor saves cursor position(CSI s)
+func (s *ANSIStrings) SCOSaveCursor() *ANSIStrings {
+	return s.setEscape("\033[s")
+}
+
+// SCORestoreCursor restores cursor position saved by SCOSaveCursor(CSI u)
+func (s *ANSIStrings) SCORestoreCursor() *ANSIStrings {
+	return s.setEscape("\033[u")
+}
+
+// EnterAltScreen switches to alternate screen buffer
+func (s *ANSIStrings) EnterAltScreen() *ANSIStrings {
+	return s.setEscape(_enterAltScreen)
+}
+
+// LeaveAltScreen switches back to main screen buffer
+func (s *ANSIStrings) LeaveAltScreen() *ANSIStrings {
+	return s.setEscape(_leaveAltScreen)
+}
+
+// BeginSync begins synchronized update(mode 2026). terminal paints nothing until EndSync.
+func (s *ANSIStrings) BeginSync() *ANSIStrings {
+	return s.setEscape(_beginSync)
+}
+
+// EndSync ends synchronized update(mode 2026) and terminal paints updated screen at once
+func (s *ANSIStrings) EndSync() *ANSIStrings {
+	return s.setEscape(_endSync)
+}
+
+// HideCursor hides cursor
+func (s *ANSIStrings) HideCursor() *ANSIStrings {
+	return s.setEscape(_hideCursor)
+}
+
+// ShowCursor shows cursor
+func (s *ANSIStrings) ShowCursor() *ANSIStrings {
+	return s.setEscape(_showCursor)
+}
+
+// CursorShape changes shape of cursor(DECSCUSR)
+func (s *ANSIStrings) CursorShape(shape CursorShape) *ANSIStrings {
+	if shape < CursorDefault || shape > CursorBar {
+		panic("unknown cursor shape")
+	}
+	return s.setEscape(fmt.Sprintf("\033[%d q", shape))
+}
+
+func (s *ANSIStrings) setEscape(e string) *ANSIStrings {
+	s.Str("")
+	s.CurrentStr().escape = e
+	return s
+}
+
 func (s *ANSIStrings) setDirection(d string, n []int) *ANSIStrings {
 	s.Str("")
 	s.CurrentStr().direction.direction = d
@@ -788,6 +956,84 @@ func (s *ANSIStrings) Clear() *ANSIStrings {
 	return s
 }
 
+// EraseLine erases whole line. cursor is not moved.
+func (s *ANSIStrings) EraseLine() *ANSIStrings {
+	return s.setEscape("\033[2K")
+}
+
+// EraseLineToEnd erases from cursor to end of line
+func (s *ANSIStrings) EraseLineToEnd() *ANSIStrings {
+	return s.setEscape("\033[0K")
+}
+
+// EraseLineToStart erases from start of line to cursor
+func (s *ANSIStrings) EraseLineToStart() *ANSIStrings {
+	return s.setEscape("\033[1K")
+}
+
+// EraseBelow erases from cursor to end of screen
+func (s *ANSIStrings) EraseBelow() *ANSIStrings {
+	return s.setEscape("\033[0J")
+}
+
+// EraseAbove erases from start of screen to cursor
+func (s *ANSIStrings) EraseAbove() *ANSIStrings {
+	return s.setEscape("\033[1J")
+}
+
+// EraseScrollback erases scrollback buffer
+func (s *ANSIStrings) EraseScrollback() *ANSIStrings {
+	return s.setEscape("\033[3J")
+}
+
+// ScrollRegion sets scroll region from top row to bottom row(DECSTBM). cursor moves to (1, 1).
+func (s *ANSIStrings) ScrollRegion(top int, bottom int) *ANSIStrings {
+	if top < 1 || bottom <= top {
+		panic("top should be greater than 0 and bottom should be greater than top")
+	}
+	return s.setEscape(fmt.Sprintf("\033[%d;%dr", top, bottom))
+}
+
+// ResetScrollRegion resets scroll region to whole screen
+func (s *ANSIStrings) ResetScrollRegion() *ANSIStrings {
+	return s.setEscape("\033[r")
+}
+
+// ScrollUp scrolls up lines in scroll region
+func (s *ANSIStrings) ScrollUp(n ...int) *ANSIStrings {
+	return s.setDirection(_scrollUp, n)
+}
+
+// ScrollDown scrolls down lines in scroll region
+func (s *ANSIStrings) ScrollDown(n ...int) *ANSIStrings {
+	return s.setDirection(_scrollDown, n)
+}
+
+// InsertLine inserts blank line(s) at cursor
+func (s *ANSIStrings) InsertLine(n ...int) *ANSIStrings {
+	return s.setDirection(_insertLine, n)
+}
+
+// DeleteLine deletes line(s) at cursor
+func (s *ANSIStrings) DeleteLine(n ...int) *ANSIStrings {
+	return s.setDirection(_deleteLine, n)
+}
+
+// InsertChar inserts blank character(s) at cursor
+func (s *ANSIStrings) InsertChar(n ...int) *ANSIStrings {
+	return s.setDirection(_insertChar, n)
+}
+
+// DeleteChar deletes character(s) at cursor
+func (s *ANSIStrings) DeleteChar(n ...int) *ANSIStrings {
+	return s.setDirection(_deleteChar, n)
+}
+
+// EraseChar erases character(s) from cursor without moving following characters
+func (s *ANSIStrings) EraseChar(n ...int) *ANSIStrings {
+	return s.setDirection(_eraseChar, n)
+}
+
 // ResetStyle resets ANSI escaping
 func (s *ANSIString) ResetStyle() {
 	str := s.Str
@@ -811,6 +1057,9 @@ func (s *ANSIStrings) ResetAll() *ANSIStrings {
 
 // Str add new ANSIString
 func (s *ANSIStrings) Str(str string) *ANSIStrings {
+	if s.sanitize != SanitizeNone {
+		str = Sanitize(str, s.sanitize)
+	}
 	s.strings = append(s.strings, ANSIString{Str: str})
 	s.index = len(s.strings) - 1
 	return s
@@ -174,3 +174,41 @@ func TestChain(t *testing.T) {
 		t.Errorf("Get %#v, want %#v", v, v2)
 	}
 }
+
+func TestCursor(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("a").SaveCursor().NextLine().PrevLine(2).Column(5).RestoreCursor().
+		SCOSaveCursor().SCORestoreCursor().HideCursor().ShowCursor().CursorShape(s.CursorBlinkingBar)
+	a := "a\0337\033[1E\033[2F\033[5G\0338\033[s\033[u\033[?25l\033[?25h\033[5 q"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	vt := s.NewVT(10, 5)
+	v = s.NewANSIStrings()
+	v.Str("abc").SaveCursor().NextLine(2).Str("d").Column(5).Str("e").PrevLine().Str("f").RestoreCursor().Str("g")
+	vt.WriteString(v.String())
+	a = "abcg\nf\nd   e"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+}
+
+func TestErase(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.EraseLine().EraseLineToEnd().EraseLineToStart().EraseBelow().EraseAbove().EraseScrollback().
+		InsertLine().DeleteLine(2).InsertChar(3).DeleteChar().EraseChar(4)
+	a := "\033[2K\033[0K\033[1K\033[0J\033[1J\033[3J\033[1L\033[2M\033[3@\033[1P\033[4X"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	vt := s.NewVT(20, 5)
+	v.Str("line1\nline2\nprogress 10%").Column(10).EraseLineToEnd().Str("50%").
+		Up().EraseLine().Str("new").Up().Column(2).DeleteChar(3)
+	vt.WriteString(v.String())
+	a = "l1\n            new\nprogress 50%"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+}
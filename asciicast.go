@@ -0,0 +1,153 @@
+package ansistrings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// CastHeader is header of asciicast v2 file
+type CastHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// CastWriter records ANSIStrings as asciicast v2.
+// Time of output is not wall clock but sum of Pause.
+type CastWriter struct {
+	w       io.Writer
+	elapsed time.Duration
+}
+
+// NewCastWriter writes header and returns new CastWriter
+func NewCastWriter(w io.Writer, header CastHeader) (*CastWriter, error) {
+	header.Version = 2
+	if header.Width == 0 {
+		header.Width = 80
+	}
+	if header.Height == 0 {
+		header.Height = 24
+	}
+	b, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(w, "%s\n", b); err != nil {
+		return nil, err
+	}
+	return &CastWriter{w: w}, nil
+}
+
+// Record records ANSI escaped strings like Print and discard them
+func (c *CastWriter) Record(s *ANSIStrings) error {
+	for _, as := range s.strings {
+		as.skipSleep = true
+		as.profile = s.profile
+		if str := as.String(); str != "" {
+			if _, err := c.WriteString(str); err != nil {
+				return err
+			}
+		}
+		c.Pause(as.sleep)
+	}
+	s.strings = make([]ANSIString, 0)
+	return nil
+}
+
+// Pause advances time of recording
+func (c *CastWriter) Pause(d time.Duration) *CastWriter {
+	c.elapsed += d
+	return c
+}
+
+// Write records output at current time
+func (c *CastWriter) Write(p []byte) (int, error) {
+	if _, err := c.WriteString(string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteString records output at current time
+func (c *CastWriter) WriteString(str string) (int, error) {
+	t := math.Round(c.elapsed.Seconds()*1e6) / 1e6
+	// recorded output is what terminal receives, so LF is CRLF
+	data := strings.ReplaceAll(strings.ReplaceAll(str, "\r\n", "\n"), "\n", "\r\n")
+	b, err := json.Marshal([]interface{}{t, "o", data})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := fmt.Fprintf(c.w, "%s\n", b); err != nil {
+		return 0, err
+	}
+	return len(str), nil
+}
+
+// CastPlayer replays asciicast v2
+type CastPlayer struct {
+	Header CastHeader
+	// Speed is playback speed. 2 means twice as fast(default 1)
+	Speed float64
+	// IdleLimit limits wait between outputs if it is not 0
+	IdleLimit time.Duration
+	dec       *json.Decoder
+}
+
+// NewCastPlayer reads header of asciicast v2 and returns new CastPlayer
+func NewCastPlayer(r io.Reader) (*CastPlayer, error) {
+	p := &CastPlayer{Speed: 1, dec: json.NewDecoder(r)}
+	if err := p.dec.Decode(&p.Header); err != nil {
+		return nil, err
+	}
+	if p.Header.Version != 2 {
+		return nil, fmt.Errorf("unsupported asciicast version: %d", p.Header.Version)
+	}
+	return p, nil
+}
+
+// Play writes output events to w with original timing
+func (p *CastPlayer) Play(w io.Writer) error {
+	if p.Speed <= 0 {
+		return errors.New("speed should be greater than 0")
+	}
+	prev := 0.0
+	for {
+		var ev []interface{}
+		if err := p.dec.Decode(&ev); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if len(ev) != 3 {
+			return fmt.Errorf("invalid asciicast event: %v", ev)
+		}
+		t, ok1 := ev[0].(float64)
+		code, ok2 := ev[1].(string)
+		data, ok3 := ev[2].(string)
+		if !ok1 || !ok2 || !ok3 {
+			return fmt.Errorf("invalid asciicast event: %v", ev)
+		}
+		if code != "o" {
+			continue
+		}
+		wait := time.Duration((t - prev) / p.Speed * float64(time.Second))
+		if p.IdleLimit > 0 && wait > p.IdleLimit {
+			wait = p.IdleLimit
+		}
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+		prev = t
+		if _, err := io.WriteString(w, data); err != nil {
+			return err
+		}
+	}
+}
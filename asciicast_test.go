@@ -0,0 +1,61 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestCast(t *testing.T) {
+	b := &bytes.Buffer{}
+	c, err := s.NewCastWriter(b, s.CastHeader{Width: 40, Height: 10, Title: "demo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := s.NewANSIStrings()
+	v.Str("a").Red().Pause(1500).Str("b").Pause(20).Clear()
+	if err := c.Record(&v); err != nil {
+		t.Fatal(err)
+	}
+	a := `{"version":2,"width":40,"height":10,"title":"demo"}
+[0,"o","\u001b[31ma\u001b[0m"]
+[1.5,"o","b"]
+[1.52,"o","\u001b[2J\u001b[1;1H"]
+`
+	if b.String() != a {
+		t.Errorf("Get %s, want %s", b.String(), a)
+	}
+
+	p, err := s.NewCastPlayer(strings.NewReader(a))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Header.Width != 40 {
+		t.Errorf("Get %d, want 40", p.Header.Width)
+	}
+	p.Speed = 100
+	out := &bytes.Buffer{}
+	if err := p.Play(out); err != nil {
+		t.Fatal(err)
+	}
+	a = "\033[31ma\033[0mb\033[2J\033[1;1H"
+	if out.String() != a {
+		t.Errorf("Get %#v, want %#v", out.String(), a)
+	}
+
+	// profile is used as same as Print
+	b.Reset()
+	c, _ = s.NewCastWriter(b, s.CastHeader{Width: 40, Height: 10})
+	v.Str("a").Link("https://example.com/").SetProfile(s.Profile{})
+	c.Record(&v)
+	if !strings.Contains(b.String(), `"a (https://example.com/)"`) {
+		t.Errorf("Get %s, want link as text", b.String())
+	}
+	v.Str("a").Link("https://example.com/").SetProfile(s.Profile{Hyperlink: true})
+	c.Record(&v)
+	if !strings.Contains(b.String(), `\u001b]8;`) {
+		t.Errorf("Get %s, want hyperlink", b.String())
+	}
+}
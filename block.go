@@ -0,0 +1,259 @@
+package ansistrings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Align is horizontal alignment
+type Align int
+
+// constant value of horizontal alignments
+const (
+	AlignLeft Align = iota
+	AlignCenter
+	AlignRight
+)
+
+// styledLine is characters and their style in a line
+type styledLine []Cell
+
+// toLines splits content into styled lines.
+// content can be ANSIStrings, ANSIString, their pointer, string or any value formatted by fmt.
+// SGR in string is applied to style and other escape sequences are dropped.
+func toLines(content interface{}) []styledLine {
+	segments := []ANSIString{}
+	switch c := content.(type) {
+	case *ANSIStrings:
+		segments = c.textSegments()
+	case ANSIStrings:
+		segments = c.textSegments()
+	case *ANSIString:
+		segments = append(segments, *c)
+	case ANSIString:
+		segments = append(segments, c)
+	case string:
+		segments = append(segments, ANSIString{Str: c})
+	default:
+		segments = append(segments, ANSIString{Str: fmt.Sprint(c)})
+	}
+	lines := []styledLine{{}}
+	for _, seg := range segments {
+		if seg.adaptive != nil {
+			seg.resolveAdaptive()
+		}
+		str := seg.Str
+		style := seg.ANSIStyle
+		style.sleep, style.skipSleep = 0, false
+		if style.link.url != "" {
+			if !seg.currentProfile().Hyperlink {
+				str = linkFallback(str, style.link.url)
+				style.UnsetLink()
+			} else {
+				// split pieces of link have same id
+				style.link.params = style.linkParams(seg.Str)
+			}
+		}
+		for _, t := range tokenize(str) {
+			switch {
+			case t.kind == tokText:
+				for _, r := range t.raw {
+					if r == '\n' {
+						lines = append(lines, styledLine{})
+						continue
+					}
+					if r == '\t' {
+						r = ' '
+					}
+					lines[len(lines)-1] = append(lines[len(lines)-1], Cell{Rune: r, Style: style})
+				}
+			case t.kind == tokCSI && t.final == 'm':
+				link := style.link
+				style.applySGR(parseParams(t.params))
+				style.link = link
+			}
+		}
+	}
+	return lines
+}
+
+// textSegments returns segments except control sequences
+func (s ANSIStrings) textSegments() []ANSIString {
+	segments := []ANSIString{}
+	for _, as := range s.strings {
+		if as.clear || as.position.x != 0 || as.direction.n != 0 || as.escape != "" {
+			continue
+		}
+		as.profile = s.profile
+		segments = append(segments, as)
+	}
+	return segments
+}
+
+// width returns visible width of line
+func (l styledLine) width() int {
+	n := 0
+	for _, c := range l {
+		n += runeWidth(c.Rune)
+	}
+	return n
+}
+
+// fit returns number of characters from head of line which fit in width
+func (l styledLine) fit(width int) int {
+	w := 0
+	for i, c := range l {
+		w += runeWidth(c.Rune)
+		if w > width {
+			return i
+		}
+	}
+	return len(l)
+}
+
+// truncate cuts line to width and puts tail at the end when it is cut
+func (l styledLine) truncate(width int, tail string) styledLine {
+	if l.width() <= width {
+		return l
+	}
+	tl := text(tail, ANSIStyle{})
+	tl = tl[:tl.fit(width)]
+	t := append(styledLine{}, l[:l.fit(width-tl.width())]...)
+	style := ANSIStyle{}
+	if len(t) > 0 {
+		style = t[len(t)-1].Style
+	}
+	for _, c := range tl {
+		t = append(t, Cell{Rune: c.Rune, Style: style})
+	}
+	return t
+}
+
+// wrap splits line to lines whose width is within width. line is split at space if possible.
+func (l styledLine) wrap(width int) []styledLine {
+	lines := []styledLine{}
+	for l.width() > width {
+		n := l.fit(width)
+		for i := n; i > 0; i-- {
+			if l[i].Rune == ' ' {
+				n = i
+				break
+			}
+		}
+		if n == 0 {
+			// character wider than width
+			n = 1
+		}
+		lines = append(lines, l[:n])
+		l = l[n:]
+		for len(l) > 0 && l[0].Rune == ' ' {
+			l = l[1:]
+		}
+	}
+	return append(lines, l)
+}
+
+// pad pads line with spaces of style to width according to align
+func (l styledLine) pad(width int, align Align, style ANSIStyle) styledLine {
+	n := width - l.width()
+	if n <= 0 {
+		return l
+	}
+	left := 0
+	switch align {
+	case AlignCenter:
+		left = n / 2
+	case AlignRight:
+		left = n
+	}
+	p := make(styledLine, 0, width)
+	p = append(p, spaces(left, style)...)
+	p = append(p, l...)
+	return append(p, spaces(n-left, style)...)
+}
+
+// background sets background of style to characters which have no background
+func (l styledLine) background(style ANSIStyle) styledLine {
+	if !style.hasBackground() {
+		return l
+	}
+	b := make(styledLine, len(l))
+	for i, c := range l {
+		if !c.Style.hasBackground() {
+			c.Style.bgColor = style.bgColor
+			c.Style.bgColorN = style.bgColorN
+			c.Style.bgRgb = style.bgRgb
+		}
+		b[i] = c
+	}
+	return b
+}
+
+func (s ANSIStyle) hasBackground() bool {
+	return s.bgColor.isSet || s.bgColorN.isSet || s.bgRgb.isSet
+}
+
+// appendTo appends line to v. characters of same style are joined.
+func (l styledLine) appendTo(v *ANSIStrings) {
+	for i := 0; i < len(l); {
+		j := i
+		str := strings.Builder{}
+		for ; j < len(l) && l[j].Style == l[i].Style; j++ {
+			str.WriteRune(l[j].Rune)
+		}
+		v.strings = append(v.strings, ANSIString{Str: str.String(), ANSIStyle: l[i].Style})
+		v.index = len(v.strings) - 1
+		i = j
+	}
+}
+
+// joinLines returns ANSIStrings of lines
+func joinLines(lines []styledLine) *ANSIStrings {
+	v := NewANSIStrings()
+	link := false
+	for i, l := range lines {
+		if i > 0 {
+			v.Str("\n")
+		}
+		l.appendTo(&v)
+		for _, c := range l {
+			link = link || c.Style.link.url != ""
+		}
+	}
+	if link {
+		// links are left by toLines only when profile of content supports hyperlink
+		p := DefaultProfile
+		p.Hyperlink = true
+		v.SetProfile(p)
+	}
+	return &v
+}
+
+// text returns line of style
+func text(str string, style ANSIStyle) styledLine {
+	l := styledLine{}
+	for _, r := range str {
+		l = append(l, Cell{Rune: r, Style: style})
+	}
+	return l
+}
+
+func spaces(n int, style ANSIStyle) styledLine {
+	return text(strings.Repeat(" ", n), style)
+}
+
+func (l styledLine) append(a styledLine) styledLine {
+	return append(append(styledLine{}, l...), a...)
+}
+
+// defaultStyle sets style to characters which have no style
+func (l styledLine) defaultStyle(style ANSIStyle) styledLine {
+	d := make(styledLine, len(l))
+	for i, c := range l {
+		if c.Style == (ANSIStyle{}) {
+			c.Style = style
+		}
+		d[i] = c
+	}
+	return d
+}
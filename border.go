@@ -0,0 +1,54 @@
+package ansistrings
+
+// Border is set of characters to draw border
+type Border struct {
+	Horizontal  string
+	Vertical    string
+	TopLeft     string
+	TopRight    string
+	BottomLeft  string
+	BottomRight string
+	// TopT, BottomT, LeftT, RightT and Cross are junctions of lines in table
+	TopT    string
+	BottomT string
+	LeftT   string
+	RightT  string
+	Cross   string
+	// markdown is whether table is drawn as markdown
+	markdown bool
+}
+
+// predefined borders
+var (
+	BorderASCII = Border{
+		Horizontal: "-", Vertical: "|",
+		TopLeft: "+", TopRight: "+", BottomLeft: "+", BottomRight: "+",
+		TopT: "+", BottomT: "+", LeftT: "+", RightT: "+", Cross: "+",
+	}
+	BorderSingle = Border{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "┌", TopRight: "┐", BottomLeft: "└", BottomRight: "┘",
+		TopT: "┬", BottomT: "┴", LeftT: "├", RightT: "┤", Cross: "┼",
+	}
+	BorderRounded = Border{
+		Horizontal: "─", Vertical: "│",
+		TopLeft: "╭", TopRight: "╮", BottomLeft: "╰", BottomRight: "╯",
+		TopT: "┬", BottomT: "┴", LeftT: "├", RightT: "┤", Cross: "┼",
+	}
+	BorderDouble = Border{
+		Horizontal: "═", Vertical: "║",
+		TopLeft: "╔", TopRight: "╗", BottomLeft: "╚", BottomRight: "╝",
+		TopT: "╦", BottomT: "╩", LeftT: "╠", RightT: "╣", Cross: "╬",
+	}
+	BorderHeavy = Border{
+		Horizontal: "━", Vertical: "┃",
+		TopLeft: "┏", TopRight: "┓", BottomLeft: "┗", BottomRight: "┛",
+		TopT: "┳", BottomT: "┻", LeftT: "┣", RightT: "┫", Cross: "╋",
+	}
+	// BorderMarkdown draws table as markdown. It has no top and bottom line.
+	BorderMarkdown = Border{
+		Horizontal: "-", Vertical: "|",
+		LeftT: "|", RightT: "|", Cross: "|",
+		markdown: true,
+	}
+)
@@ -0,0 +1,107 @@
+package ansistrings
+
+import "strings"
+
+// Spacing is size of space of each side
+type Spacing struct {
+	Top    int
+	Right  int
+	Bottom int
+	Left   int
+}
+
+// Box renders content surrounded by border.
+// Content can be ANSIStrings, ANSIString, string or any value and width of it is computed from visible text.
+type Box struct {
+	Border      Border
+	BorderStyle ANSIStyle
+	// Title is drawn in top border
+	Title      string
+	TitleStyle ANSIStyle
+	TitleAlign Align
+	// Width is fixed width inside border. It is computed from content if it is 0.
+	// Lines wider than it are wrapped.
+	Width int
+	// Align is alignment of content
+	Align   Align
+	Padding Spacing
+	Margin  Spacing
+}
+
+// NewBox returns new Box which has single border and padding of one space at left and right
+func NewBox() *Box {
+	return &Box{Border: BorderSingle, Padding: Spacing{Left: 1, Right: 1}}
+}
+
+// Render returns ANSIStrings of content surrounded by Box
+func (b *Box) Render(content interface{}) *ANSIStrings {
+	lines := toLines(content)
+	title := text(b.Title, b.TitleStyle)
+	inner := b.Width
+	if inner <= 0 {
+		for _, l := range lines {
+			if l.width() > inner {
+				inner = l.width()
+			}
+		}
+		inner += b.Padding.Left + b.Padding.Right
+		if len(title) > 0 && inner < title.width()+4 {
+			// title needs spaces around it and horizontal lines at both sides
+			inner = title.width() + 4
+		}
+	}
+	width := inner - b.Padding.Left - b.Padding.Right
+	if width < 1 {
+		width = 1
+	}
+
+	body := []styledLine{}
+	for i := 0; i < b.Padding.Top; i++ {
+		body = append(body, styledLine{})
+	}
+	for _, l := range lines {
+		body = append(body, l.wrap(width)...)
+	}
+	for i := 0; i < b.Padding.Bottom; i++ {
+		body = append(body, styledLine{})
+	}
+
+	left := spaces(b.Margin.Left, ANSIStyle{})
+	right := spaces(b.Margin.Right, ANSIStyle{})
+	out := []styledLine{}
+	for i := 0; i < b.Margin.Top; i++ {
+		out = append(out, styledLine{})
+	}
+	out = append(out, left.append(b.top(inner, title)).append(right))
+	vertical := text(b.Border.Vertical, b.BorderStyle)
+	for _, l := range body {
+		l = spaces(b.Padding.Left, ANSIStyle{}).append(l.pad(width, b.Align, ANSIStyle{}))
+		l = l.pad(inner, AlignLeft, ANSIStyle{})
+		out = append(out, left.append(vertical).append(l).append(vertical).append(right))
+	}
+	bottom := text(b.Border.BottomLeft+strings.Repeat(b.Border.Horizontal, inner)+b.Border.BottomRight, b.BorderStyle)
+	out = append(out, left.append(bottom).append(right))
+	for i := 0; i < b.Margin.Bottom; i++ {
+		out = append(out, styledLine{})
+	}
+	return joinLines(out)
+}
+
+// top returns top border with title
+func (b *Box) top(inner int, title styledLine) styledLine {
+	if inner < 5 || len(title) == 0 {
+		return text(b.Border.TopLeft+strings.Repeat(b.Border.Horizontal, inner)+b.Border.TopRight, b.BorderStyle)
+	}
+	title = title.truncate(inner-4, "…")
+	rest := inner - title.width() - 2
+	lead := 1
+	switch b.TitleAlign {
+	case AlignCenter:
+		lead = rest / 2
+	case AlignRight:
+		lead = rest - 1
+	}
+	l := text(b.Border.TopLeft+strings.Repeat(b.Border.Horizontal, lead)+" ", b.BorderStyle)
+	l = l.append(title)
+	return l.append(text(" "+strings.Repeat(b.Border.Horizontal, rest-lead)+b.Border.TopRight, b.BorderStyle))
+}
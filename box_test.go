@@ -0,0 +1,53 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestBox(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("status: ").Str("ok").Green().Str("\nuptime: 3d")
+	b := s.NewBox()
+	b.Border = s.BorderRounded
+	b.BorderStyle.Blue()
+	b.Title = "server"
+	b.Margin = s.Spacing{Top: 1, Left: 2}
+	vt := s.NewVT(30, 0)
+	vt.WriteString(b.Render(&v).String())
+	a := "\n  ╭─ server ───╮\n  │ status: ok │\n  │ uptime: 3d │\n  ╰────────────╯"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	blue := s.NewANSIStyle()
+	blue.Blue()
+	green := s.NewANSIStyle()
+	green.Green()
+	if c := vt.Cell(3, 2); c.Style != blue {
+		t.Errorf("Get %#v, want blue", c)
+	}
+	if c := vt.Cell(13, 3); c.Rune != 'o' || c.Style != green {
+		t.Errorf("Get %#v, want green", c)
+	}
+
+	b = s.NewBox()
+	b.Border = s.BorderASCII
+	b.Width = 8
+	b.Padding = s.Spacing{Top: 1, Left: 1, Right: 1}
+	b.Align = s.AlignCenter
+	b.Title = "long title"
+	a = "+- lon… -+\n|        |\n| a long |\n|  text  |\n+--------+"
+	if str := b.Render("a long text").String(); str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
+
+func TestBoxWide(t *testing.T) {
+	b := s.NewBox()
+	b.Title = "情報"
+	a := "┌─ 情報 ─┐\n│ 日本   │\n│ ok     │\n└────────┘"
+	if str := b.Render("日本\nok").String(); str != a {
+		t.Errorf("Get\n%s\nwant\n%s", str, a)
+	}
+}
@@ -0,0 +1,61 @@
+package ansistrings
+
+import (
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// ClipboardTarget is target of OSC 52
+type ClipboardTarget string
+
+// constant value of clipboard targets
+const (
+	ClipboardSystem    ClipboardTarget = "c"
+	ClipboardPrimary   ClipboardTarget = "p"
+	ClipboardSelection ClipboardTarget = "s"
+)
+
+// MaxClipboardSize is max bytes of text copied to clipboard.
+// Many terminals ignore OSC 52 sequence longer than 100000 bytes.
+var MaxClipboardSize = 74994
+
+// ErrClipboardTooLarge is returned when text is larger than MaxClipboardSize
+var ErrClipboardTooLarge = errors.New("text is too large to copy to clipboard")
+
+// clipboardSequence returns OSC 52 sequence to copy text to clipboard
+func clipboardSequence(text string, targets []ClipboardTarget) (string, error) {
+	if len(text) > MaxClipboardSize {
+		return "", ErrClipboardTooLarge
+	}
+	t := ""
+	for _, target := range targets {
+		t += string(target)
+	}
+	if t == "" {
+		t = string(ClipboardSystem)
+	}
+	return "\033]52;" + t + ";" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a", nil
+}
+
+// Clipboard copies text to clipboard(OSC 52). default target is ClipboardSystem.
+// text larger than MaxClipboardSize is skipped. Use CopyToClipboard to get the error.
+func (s *ANSIStrings) Clipboard(text string, targets ...ClipboardTarget) *ANSIStrings {
+	seq, err := clipboardSequence(text, targets)
+	if err != nil {
+		return s
+	}
+	s.setEscape(seq)
+	s.CurrentStr().passthrough = true
+	return s
+}
+
+// CopyToClipboard writes OSC 52 sequence to copy text to clipboard. default target is ClipboardSystem.
+func CopyToClipboard(w io.Writer, text string, targets ...ClipboardTarget) error {
+	seq, err := clipboardSequence(text, targets)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, DefaultProfile.Multiplexer.wrap(seq))
+	return err
+}
@@ -0,0 +1,40 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestClipboard(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.SetProfile(s.Profile{})
+	v.Clipboard("hello").Clipboard("hi", s.ClipboardSystem, s.ClipboardPrimary)
+	a := "\033]52;c;aGVsbG8=\a\033]52;cp;aGk=\a"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	v.SetProfile(s.Profile{Multiplexer: s.MultiplexerTmux})
+	a = "\033Ptmux;\033\033]52;c;aGVsbG8=\a\033\\\033Ptmux;\033\033]52;cp;aGk=\a\033\\"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	v = s.NewANSIStrings()
+	v.SetProfile(s.Profile{})
+	v.Str("a").Clipboard(strings.Repeat("a", s.MaxClipboardSize+1)).Str("b")
+	if v.String() != "ab" {
+		t.Errorf("Get %#v, want %#v", v.String(), "ab")
+	}
+
+	b := &bytes.Buffer{}
+	if err := s.CopyToClipboard(b, strings.Repeat("a", s.MaxClipboardSize+1)); err != s.ErrClipboardTooLarge {
+		t.Errorf("Get %v, want %v", err, s.ErrClipboardTooLarge)
+	}
+	if err := s.CopyToClipboard(b, "hello", s.ClipboardPrimary); err != nil || !strings.Contains(b.String(), "\033]52;p;aGVsbG8=\a") {
+		t.Errorf("Get %#v, %v", b.String(), err)
+	}
+}
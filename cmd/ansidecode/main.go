@@ -0,0 +1,27 @@
+// Command ansidecode prints ANSI escaped input from stdin in readable form
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func main() {
+	explain := flag.Bool("explain", false, "explain each escape sequence per line")
+	flag.Parse()
+
+	b, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *explain {
+		fmt.Println(s.Explain(string(b)))
+	} else {
+		fmt.Print(s.Decode(string(b)))
+	}
+}
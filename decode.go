@@ -0,0 +1,257 @@
+package ansistrings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sequenceDesc is description of escape sequence.
+// tag is used by Decode and text is used by Explain.
+type sequenceDesc struct {
+	tag  string
+	text string
+}
+
+var sgrDescs = map[int]sequenceDesc{
+	0:  {"/reset", "reset"},
+	1:  {"bold", "bold"},
+	2:  {"faint", "faint"},
+	3:  {"italic", "italic"},
+	4:  {"underline", "underline"},
+	5:  {"blink", "blink"},
+	6:  {"rapid_blink", "rapid blink"},
+	7:  {"inverted", "inverted"},
+	8:  {"conceal", "conceal"},
+	9:  {"delete", "crossed out"},
+	22: {"/bold", "normal intensity"},
+	23: {"/italic", "not italic"},
+	24: {"/underline", "not underlined"},
+	25: {"/blink", "not blinking"},
+	27: {"/inverted", "not inverted"},
+	28: {"/conceal", "not concealed"},
+	29: {"/delete", "not crossed out"},
+	39: {"/fg", "default fg"},
+	49: {"/bg", "default bg"},
+}
+
+var cursorDescs = map[byte]sequenceDesc{
+	'A': {"up", "cursor up"},
+	'B': {"down", "cursor down"},
+	'C': {"forward", "cursor forward"},
+	'D': {"back", "cursor back"},
+	'E': {"next_line", "cursor next line"},
+	'F': {"prev_line", "cursor previous line"},
+	'G': {"column", "cursor column"},
+	'L': {"insert_line", "insert line"},
+	'M': {"delete_line", "delete line"},
+	'@': {"insert_char", "insert character"},
+	'P': {"delete_char", "delete character"},
+	'X': {"erase_char", "erase character"},
+	'S': {"scroll_up", "scroll up"},
+	'T': {"scroll_down", "scroll down"},
+}
+
+var eraseDescs = map[byte][]string{
+	'J': {"below", "above", "screen", "scrollback"},
+	'K': {"to end of line", "to start of line", "line"},
+}
+
+var modeDescs = map[int]string{
+	25:   "cursor",
+	47:   "alt_screen",
+	1047: "alt_screen",
+	1049: "alt_screen",
+	2004: "bracketed_paste",
+	2026: "sync",
+}
+
+func colorName(c int) string {
+	for name, n := range name2color {
+		if n == c {
+			return name
+		}
+	}
+	return strconv.Itoa(c)
+}
+
+// describeSGR returns descriptions of SGR parameters
+func describeSGR(n []int) []sequenceDesc {
+	if len(n) == 0 {
+		return []sequenceDesc{sgrDescs[0]}
+	}
+	descs := []sequenceDesc{}
+	for i := 0; i < len(n); i++ {
+		p := n[i]
+		if d, ok := sgrDescs[p]; ok {
+			descs = append(descs, d)
+			continue
+		}
+		switch {
+		case p >= 11 && p <= 20:
+			descs = append(descs, sequenceDesc{fmt.Sprintf("font:%d", p-10), fmt.Sprintf("font %d", p-10)})
+		case (p >= 30 && p <= 37) || (p >= 90 && p <= 97):
+			name := colorName(p)
+			descs = append(descs, sequenceDesc{"fg:" + name, "set fg " + name})
+		case (p >= 40 && p <= 47) || (p >= 100 && p <= 107):
+			name := colorName(p - 10)
+			descs = append(descs, sequenceDesc{"bg:" + name, "set bg " + name})
+		case (p == 38 || p == 48) && i+2 < len(n) && n[i+1] == 5:
+			layer := map[int]string{38: "fg", 48: "bg"}[p]
+			descs = append(descs, sequenceDesc{fmt.Sprintf("%s:%d", layer, n[i+2]), fmt.Sprintf("set %s 256 colors(%d)", layer, n[i+2])})
+			i += 2
+		case (p == 38 || p == 48) && i+4 < len(n) && n[i+1] == 2:
+			layer := map[int]string{38: "fg", 48: "bg"}[p]
+			rgb := fmt.Sprintf("%d,%d,%d", n[i+2], n[i+3], n[i+4])
+			descs = append(descs, sequenceDesc{fmt.Sprintf("%s:rgb(%s)", layer, rgb), fmt.Sprintf("set %s RGB(%s)", layer, rgb)})
+			i += 4
+		default:
+			descs = append(descs, sequenceDesc{fmt.Sprintf("sgr:%d", p), fmt.Sprintf("unknown SGR %d", p)})
+		}
+	}
+	return descs
+}
+
+// describe returns descriptions of token which is not text
+func describe(t token) []sequenceDesc {
+	switch t.kind {
+	case tokCtrl:
+		name := fmt.Sprintf("0x%02x", t.final)
+		switch t.final {
+		case '\a':
+			name = "BEL"
+		case '\b':
+			name = "BS"
+		case '\r':
+			name = "CR"
+		case '\033':
+			name = "ESC"
+		}
+		return []sequenceDesc{{name, "control character " + name}}
+	case tokEsc:
+		switch t.final {
+		case '7':
+			return []sequenceDesc{{"save_cursor", "save cursor"}}
+		case '8':
+			return []sequenceDesc{{"restore_cursor", "restore cursor"}}
+		}
+		return []sequenceDesc{{"esc:" + string(t.final), "escape " + string(t.final)}}
+	case tokOSC:
+		cmd := strings.SplitN(t.params, ";", 2)
+		desc := map[string]string{"0": "set title", "1": "set icon name", "2": "set window title", "8": "hyperlink", "52": "clipboard"}[cmd[0]]
+		if desc == "" {
+			desc = "OSC " + cmd[0]
+		}
+		return []sequenceDesc{{"osc:" + cmd[0], desc}}
+	case tokString:
+		return []sequenceDesc{{"string:" + string(t.final), "control string"}}
+	}
+
+	n := parseParams(t.params)
+	arg := func(i int, def int) int {
+		if i < len(n) && n[i] > 0 {
+			return n[i]
+		}
+		return def
+	}
+	if strings.HasPrefix(t.params, "?") && (t.final == 'h' || t.final == 'l') {
+		descs := []sequenceDesc{}
+		for _, m := range n {
+			name, ok := modeDescs[m]
+			if !ok {
+				name = fmt.Sprintf("mode:%d", m)
+			}
+			if m == 25 {
+				name = map[byte]string{'h': "show_cursor", 'l': "hide_cursor"}[t.final]
+			} else if t.final == 'l' {
+				name = "/" + name
+			}
+			descs = append(descs, sequenceDesc{name, map[byte]string{'h': "set ", 'l': "reset "}[t.final] + fmt.Sprintf("mode ?%d", m)})
+		}
+		return descs
+	}
+	switch t.final {
+	case 'm':
+		return describeSGR(n)
+	case 'H', 'f':
+		return []sequenceDesc{{fmt.Sprintf("pos:%d,%d", arg(1, 1), arg(0, 1)), fmt.Sprintf("cursor position column %d, row %d", arg(1, 1), arg(0, 1))}}
+	case 'J', 'K':
+		m := arg(0, 0)
+		names := eraseDescs[t.final]
+		if m >= len(names) {
+			break
+		}
+		if t.final == 'J' && m == 2 {
+			return []sequenceDesc{{"clear", "erase screen"}}
+		}
+		return []sequenceDesc{{fmt.Sprintf("erase_%s:%d", map[byte]string{'J': "display", 'K': "line"}[t.final], m), "erase " + names[m]}}
+	case 's':
+		return []sequenceDesc{{"save_cursor", "save cursor"}}
+	case 'u':
+		return []sequenceDesc{{"restore_cursor", "restore cursor"}}
+	case 'r':
+		return []sequenceDesc{{"scroll_region:" + t.params, "set scroll region " + t.params}}
+	case 'q':
+		if strings.HasSuffix(t.params, " ") {
+			return []sequenceDesc{{fmt.Sprintf("cursor_shape:%d", arg(0, 0)), fmt.Sprintf("set cursor shape %d", arg(0, 0))}}
+		}
+	}
+	if d, ok := cursorDescs[t.final]; ok {
+		return []sequenceDesc{{fmt.Sprintf("%s:%d", d.tag, arg(0, 1)), fmt.Sprintf("%s %d", d.text, arg(0, 1))}}
+	}
+	return []sequenceDesc{{"csi:" + t.params + string(t.final), "unknown"}}
+}
+
+// Decode returns ANSI escaped string with readable tags like "<fg:red><bold>test</reset>"
+func Decode(s string) string {
+	b := strings.Builder{}
+	for _, t := range tokenize(s) {
+		if t.kind == tokText {
+			b.WriteString(t.raw)
+			continue
+		}
+		for _, d := range describe(t) {
+			b.WriteString("<" + d.tag + ">")
+		}
+	}
+	return b.String()
+}
+
+// Explain returns explanation of ANSI escaped string. each text and sequence is explained per line like
+//
+//	CSI 38;2;1;2;3 m → set fg RGB(1,2,3)
+func Explain(s string) string {
+	lines := []string{}
+	for _, t := range tokenize(s) {
+		var seq string
+		switch t.kind {
+		case tokText:
+			lines = append(lines, fmt.Sprintf("text %q", t.raw))
+			continue
+		case tokCSI:
+			seq = "CSI " + t.params + " " + string(t.final)
+		case tokOSC:
+			seq = "OSC " + t.params
+		case tokEsc:
+			seq = "ESC " + string(t.final)
+		default:
+			seq = fmt.Sprintf("%q", t.raw)
+		}
+		texts := []string{}
+		for _, d := range describe(t) {
+			texts = append(texts, d.text)
+		}
+		lines = append(lines, seq+" → "+strings.Join(texts, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Decode returns ANSI escaped string with readable tags
+func (s *ANSIStrings) Decode() string {
+	return Decode(s.String())
+}
+
+// Decode returns ANSI escaped string with readable tags
+func (s *ANSIString) Decode() string {
+	return Decode(s.String())
+}
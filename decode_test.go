@@ -0,0 +1,32 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestDecode(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("test").Red().Bold().Str("2").RGB(1, 2, 3).BgColorN(100).Pos(3, 4).Up(2)
+	a := "<fg:red><bold>test</reset><fg:rgb(1,2,3)><bg:100>2</reset><pos:3,4><up:2>"
+	if v.Decode() != a {
+		t.Errorf("Get %#v, want %#v", v.Decode(), a)
+	}
+
+	a = "<clear><pos:1,1><hide_cursor>a\n<BEL>"
+	if d := s.Decode("\033[2J\033[1;1H\033[?25la\n\a"); d != a {
+		t.Errorf("Get %#v, want %#v", d, a)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	a := `CSI 38;2;1;2;3 m → set fg RGB(1,2,3)
+text "test"
+CSI 0 m → reset
+CSI 2 K → erase line
+OSC 0;title → set title`
+	if e := s.Explain("\033[38;2;1;2;3mtest\033[0m\033[2K\033]0;title\a"); e != a {
+		t.Errorf("Get %s, want %s", e, a)
+	}
+}
@@ -0,0 +1,85 @@
+package ansistrings
+
+import "strings"
+
+const (
+	tokText = iota
+	tokCtrl
+	tokEsc
+	tokCSI
+	tokOSC
+	tokString
+)
+
+// token is piece of ANSI escaped string
+type token struct {
+	kind int
+	raw  string
+	// params is parameter of CSI(including private prefix like "?") or data of OSC
+	params string
+	// final is final byte of CSI or byte after ESC
+	final byte
+}
+
+// tokenize splits string into text, control characters and escape sequences
+func tokenize(s string) []token {
+	tokens := []token{}
+	text := strings.Builder{}
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, token{kind: tokText, raw: text.String()})
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c != '\033' && (c >= 0x20 || c == '\n' || c == '\t') && c != 0x7f {
+			text.WriteByte(c)
+			i++
+			continue
+		}
+		flush()
+		if c != '\033' || i+1 >= len(s) {
+			tokens = append(tokens, token{kind: tokCtrl, raw: s[i : i+1], final: c})
+			i++
+			continue
+		}
+		switch s[i+1] {
+		case '[':
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			if j >= len(s) {
+				tokens = append(tokens, token{kind: tokCSI, raw: s[i:], params: s[i+2:]})
+				return tokens
+			}
+			tokens = append(tokens, token{kind: tokCSI, raw: s[i : j+1], params: s[i+2 : j], final: s[j]})
+			i = j + 1
+		case ']', 'P', '_', '^', 'X':
+			kind := tokOSC
+			if s[i+1] != ']' {
+				kind = tokString
+			}
+			j := i + 2
+			end := len(s)
+			for ; j < len(s); j++ {
+				if s[j] == '\a' {
+					end = j + 1
+					break
+				}
+				if s[j] == '\033' && j+1 < len(s) && s[j+1] == '\\' {
+					end = j + 2
+					break
+				}
+			}
+			tokens = append(tokens, token{kind: kind, raw: s[i:end], params: s[i+2 : j], final: s[i+1]})
+			i = end
+		default:
+			tokens = append(tokens, token{kind: tokEsc, raw: s[i : i+2], final: s[i+1]})
+			i += 2
+		}
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,244 @@
+package ansistrings
+
+// font5x7 is bitmap font for ' ' to '~'. Each glyph is 5 columns and bit 0 is top row.
+var font5x7 = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x00, 0x00, 0x5F, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7F, 0x14, 0x7F, 0x14}, // #
+	{0x24, 0x2A, 0x7F, 0x2A, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x56, 0x20, 0x50}, // &
+	{0x00, 0x08, 0x07, 0x03, 0x00}, // '
+	{0x00, 0x1C, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1C, 0x00}, // )
+	{0x2A, 0x1C, 0x7F, 0x1C, 0x2A}, // *
+	{0x08, 0x08, 0x3E, 0x08, 0x08}, // +
+	{0x00, 0x80, 0x70, 0x30, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x00, 0x60, 0x60, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3E, 0x51, 0x49, 0x45, 0x3E}, // 0
+	{0x00, 0x42, 0x7F, 0x40, 0x00}, // 1
+	{0x72, 0x49, 0x49, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x49, 0x4D, 0x33}, // 3
+	{0x18, 0x14, 0x12, 0x7F, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3C, 0x4A, 0x49, 0x49, 0x31}, // 6
+	{0x41, 0x21, 0x11, 0x09, 0x07}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x46, 0x49, 0x49, 0x29, 0x1E}, // 9
+	{0x00, 0x00, 0x14, 0x00, 0x00}, // :
+	{0x00, 0x40, 0x34, 0x00, 0x00}, // ;
+	{0x00, 0x08, 0x14, 0x22, 0x41}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x59, 0x09, 0x06}, // ?
+	{0x3E, 0x41, 0x5D, 0x59, 0x4E}, // @
+	{0x7C, 0x12, 0x11, 0x12, 0x7C}, // A
+	{0x7F, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3E, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7F, 0x41, 0x41, 0x41, 0x3E}, // D
+	{0x7F, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7F, 0x09, 0x09, 0x09, 0x01}, // F
+	{0x3E, 0x41, 0x41, 0x51, 0x73}, // G
+	{0x7F, 0x08, 0x08, 0x08, 0x7F}, // H
+	{0x00, 0x41, 0x7F, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3F, 0x01}, // J
+	{0x7F, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7F, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7F, 0x02, 0x1C, 0x02, 0x7F}, // M
+	{0x7F, 0x04, 0x08, 0x10, 0x7F}, // N
+	{0x3E, 0x41, 0x41, 0x41, 0x3E}, // O
+	{0x7F, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3E, 0x41, 0x51, 0x21, 0x5E}, // Q
+	{0x7F, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x26, 0x49, 0x49, 0x49, 0x32}, // S
+	{0x03, 0x01, 0x7F, 0x01, 0x03}, // T
+	{0x3F, 0x40, 0x40, 0x40, 0x3F}, // U
+	{0x1F, 0x20, 0x40, 0x20, 0x1F}, // V
+	{0x3F, 0x40, 0x38, 0x40, 0x3F}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x03, 0x04, 0x78, 0x04, 0x03}, // Y
+	{0x61, 0x59, 0x49, 0x4D, 0x43}, // Z
+	{0x00, 0x7F, 0x41, 0x41, 0x41}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // \
+	{0x00, 0x41, 0x41, 0x41, 0x7F}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x03, 0x07, 0x08, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x78, 0x40}, // a
+	{0x7F, 0x28, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x28}, // c
+	{0x38, 0x44, 0x44, 0x28, 0x7F}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x00, 0x08, 0x7E, 0x09, 0x02}, // f
+	{0x18, 0xA4, 0xA4, 0x9C, 0x78}, // g
+	{0x7F, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7D, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x40, 0x3D, 0x00}, // j
+	{0x7F, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7F, 0x40, 0x00}, // l
+	{0x7C, 0x04, 0x78, 0x04, 0x78}, // m
+	{0x7C, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0xFC, 0x18, 0x24, 0x24, 0x18}, // p
+	{0x18, 0x24, 0x24, 0x18, 0xFC}, // q
+	{0x7C, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x24}, // s
+	{0x04, 0x04, 0x3F, 0x44, 0x24}, // t
+	{0x3C, 0x40, 0x40, 0x20, 0x7C}, // u
+	{0x1C, 0x20, 0x40, 0x20, 0x1C}, // v
+	{0x3C, 0x40, 0x30, 0x40, 0x3C}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x4C, 0x90, 0x90, 0x90, 0x7C}, // y
+	{0x44, 0x64, 0x54, 0x4C, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x77, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x02, 0x01, 0x02, 0x04, 0x02}, // ~
+}
+
+// unknownGlyph is drawn for characters which font5x7 doesn't have
+var unknownGlyph = [5]byte{0x7F, 0x41, 0x41, 0x41, 0x7F}
+
+func glyph(r rune) [5]byte {
+	if r >= ' ' && r <= '~' {
+		return font5x7[r-' ']
+	}
+	return unknownGlyph
+}
+
+// boxArms are weights of arms(up, right, down, left) of box drawing characters from U+2500.
+// 0 is none, 1 is light, 2 is heavy and 3 is double. "" is drawn by other way.
+var boxArms = [0x80]string{
+	"0101", "0202", "1010", "2020", "0101", "0202", "1010", "2020",
+	"0101", "0202", "1010", "2020", "0110", "0210", "0120", "0220",
+	"0011", "0012", "0021", "0022", "1100", "1200", "2100", "2200",
+	"1001", "1002", "2001", "2002", "1110", "1210", "2110", "1120",
+	"2120", "2210", "1220", "2220", "1011", "1012", "2011", "1021",
+	"2021", "2012", "1022", "2022", "0111", "0112", "0211", "0212",
+	"0121", "0122", "0221", "0222", "1101", "1102", "1201", "1202",
+	"2101", "2102", "2201", "2202", "1111", "1112", "1211", "1212",
+	"2111", "1121", "2121", "2112", "2211", "1122", "1221", "2212",
+	"1222", "2122", "2221", "2222", "0101", "0202", "1010", "2020",
+	"0303", "3030", "0310", "0130", "0330", "0013", "0031", "0033",
+	"1300", "3100", "3300", "1003", "3001", "3003", "1310", "3130",
+	"3330", "1013", "3031", "3033", "0313", "0131", "0333", "1303",
+	"3101", "3303", "1313", "3131", "3333", "0110", "0011", "1001",
+	"1100", "", "", "", "0001", "1000", "0100", "0010",
+	"0002", "2000", "0200", "0020", "0201", "1020", "0102", "2010",
+}
+
+// cellGlyph returns pixels of box drawing and block characters(U+2500 - U+259F) which fill whole cell.
+// It returns nil for other characters.
+func cellGlyph(r rune) *[_cellHeight][_cellWidth]bool {
+	if r < 0x2500 || r > 0x259f {
+		return nil
+	}
+	g := &[_cellHeight][_cellWidth]bool{}
+	fill := func(x0 int, y0 int, x1 int, y1 int) {
+		for y := y0; y < y1 && y < _cellHeight; y++ {
+			for x := x0; x < x1 && x < _cellWidth; x++ {
+				g[y][x] = true
+			}
+		}
+	}
+	if r < 0x2580 {
+		arms := boxArms[r-0x2500]
+		if arms == "" {
+			// diagonals
+			for y := 0; y < _cellHeight; y++ {
+				x := y * _cellWidth / _cellHeight
+				if r != 0x2572 {
+					g[y][_cellWidth-1-x] = true
+				}
+				if r != 0x2571 {
+					g[y][x] = true
+				}
+			}
+			return g
+		}
+		// center of cell is (2, 4)
+		for i, w := range arms {
+			x0, y0, x1, y1 := 2, 4, 3, 5
+			switch i {
+			case 0:
+				y0 = 0
+			case 1:
+				x1 = _cellWidth
+			case 2:
+				y1 = _cellHeight
+			case 3:
+				x0 = 0
+			}
+			vertical := i%2 == 0
+			switch w {
+			case '1':
+				fill(x0, y0, x1, y1)
+			case '2':
+				if vertical {
+					fill(x0, y0, x1+1, y1)
+				} else {
+					fill(x0, y0, x1, y1+1)
+				}
+			case '3':
+				if vertical {
+					fill(x0-1, y0, x1-1, y1)
+					fill(x0+1, y0, x1+1, y1)
+				} else {
+					fill(x0, y0-1, x1, y1-1)
+					fill(x0, y0+1, x1, y1+1)
+				}
+			}
+		}
+		return g
+	}
+	eighthsX := func(n int) int { return (_cellWidth*n + 4) / 8 }
+	eighthsY := func(n int) int { return (_cellHeight*n + 4) / 8 }
+	hw, hh := _cellWidth/2, _cellHeight/2
+	switch {
+	case r == 0x2580:
+		fill(0, 0, _cellWidth, hh)
+	case r >= 0x2581 && r <= 0x2588:
+		fill(0, _cellHeight-eighthsY(int(r-0x2580)), _cellWidth, _cellHeight)
+	case r >= 0x2589 && r <= 0x258f:
+		fill(0, 0, eighthsX(int(0x2590-r)), _cellHeight)
+	case r == 0x2590:
+		fill(hw, 0, _cellWidth, _cellHeight)
+	case r >= 0x2591 && r <= 0x2593:
+		// shades
+		for y := 0; y < _cellHeight; y++ {
+			for x := 0; x < _cellWidth; x++ {
+				switch r {
+				case 0x2591:
+					g[y][x] = x%2 == 0 && y%2 == 0
+				case 0x2592:
+					g[y][x] = (x+y)%2 == 0
+				default:
+					g[y][x] = x%2 != 0 || y%2 != 0
+				}
+			}
+		}
+	case r == 0x2594:
+		fill(0, 0, _cellWidth, eighthsY(1))
+	case r == 0x2595:
+		fill(_cellWidth-eighthsX(1), 0, _cellWidth, _cellHeight)
+	default:
+		// quadrants of upper left, upper right, lower left and lower right
+		q := [...]string{"0010", "0001", "1000", "1011", "1001", "1110", "1101", "0100", "0110", "0111"}[r-0x2596]
+		if q[0] == '1' {
+			fill(0, 0, hw, hh)
+		}
+		if q[1] == '1' {
+			fill(hw, 0, _cellWidth, hh)
+		}
+		if q[2] == '1' {
+			fill(0, hh, hw, _cellHeight)
+		}
+		if q[3] == '1' {
+			fill(hw, hh, _cellWidth, _cellHeight)
+		}
+	}
+	return g
+}
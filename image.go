@@ -0,0 +1,197 @@
+package ansistrings
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+)
+
+// ImageOptions is settings to render ANSIStrings as image with embedded bitmap font
+type ImageOptions struct {
+	// Columns is width of screen(default 80)
+	Columns int
+	// Rows is height of screen. 0 means fitting to output.
+	Rows int
+	// Scale is magnification of bitmap font(default 2)
+	Scale   int
+	Padding int
+	Theme   Theme
+}
+
+// NewImageOptions returns new ImageOptions with default settings
+func NewImageOptions() ImageOptions {
+	return ImageOptions{Columns: 80, Scale: 2, Padding: 8, Theme: DefaultTheme}
+}
+
+const (
+	_cellWidth  = 6
+	_cellHeight = 10
+)
+
+func (opt *ImageOptions) normalize() {
+	if opt.Columns < 1 {
+		opt.Columns = 80
+	}
+	if opt.Scale < 1 {
+		opt.Scale = 1
+	}
+	if opt.Padding < 0 {
+		opt.Padding = 0
+	}
+}
+
+// Image returns image of ANSIStrings rendered with embedded bitmap font
+func (s *ANSIStrings) Image(opt ImageOptions) image.Image {
+	opt.normalize()
+	v := NewVT(opt.Columns, opt.Rows)
+	v.WriteString(s.String())
+	return drawScreen(v.rows(), opt)
+}
+
+// WritePNG writes PNG image of ANSIStrings
+func (s *ANSIStrings) WritePNG(w io.Writer, opt ImageOptions) error {
+	return png.Encode(w, s.Image(opt))
+}
+
+// GIF returns animated GIF of ANSIStrings. Pause becomes delay of frame.
+func (s *ANSIStrings) GIF(opt ImageOptions) *gif.GIF {
+	opt.normalize()
+	v := NewVT(opt.Columns, opt.Rows)
+	type frame struct {
+		rows  [][]Cell
+		delay int
+	}
+	frames := []frame{}
+	snapshot := func(delay int) {
+		rows := v.rows()
+		copied := make([][]Cell, len(rows))
+		for i := range rows {
+			copied[i] = append([]Cell{}, rows[i]...)
+		}
+		frames = append(frames, frame{rows: copied, delay: delay})
+	}
+	for _, as := range s.strings {
+		as.skipSleep = true
+		as.profile = s.profile
+		v.WriteString(as.String())
+		if as.sleep != 0 {
+			snapshot(int(as.sleep.Milliseconds() / 10))
+		}
+	}
+	snapshot(0)
+
+	// every frame has same size as the biggest one
+	maxRows := 0
+	for _, f := range frames {
+		if len(f.rows) > maxRows {
+			maxRows = len(f.rows)
+		}
+	}
+	g := &gif.GIF{}
+	for _, f := range frames {
+		for len(f.rows) < maxRows {
+			f.rows = append(f.rows, v.blankRow())
+		}
+		g.Image = append(g.Image, toPaletted(drawScreen(f.rows, opt)))
+		g.Delay = append(g.Delay, f.delay)
+	}
+	return g
+}
+
+// WriteGIF writes animated GIF of ANSIStrings
+func (s *ANSIStrings) WriteGIF(w io.Writer, opt ImageOptions) error {
+	return gif.EncodeAll(w, s.GIF(opt))
+}
+
+func drawScreen(rows [][]Cell, opt ImageOptions) *image.RGBA {
+	t := opt.Theme
+	sc := opt.Scale
+	cw := _cellWidth * sc
+	ch := _cellHeight * sc
+	width := opt.Columns*cw + opt.Padding*2
+	height := len(rows)*ch + opt.Padding*2
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(t.Background), image.Point{}, draw.Src)
+
+	for y, row := range rows {
+		for x, c := range row {
+			ox := opt.Padding + x*cw
+			oy := opt.Padding + y*ch
+			fg, bg := t.colors(c.Style)
+			if bg != t.Background {
+				draw.Draw(img, image.Rect(ox, oy, ox+cw, oy+ch), image.NewUniform(bg), image.Point{}, draw.Src)
+			}
+			if c.Style.withFaint {
+				fg = color.RGBA{uint8((int(fg.R) + int(bg.R)) / 2), uint8((int(fg.G) + int(bg.G)) / 2), uint8((int(fg.B) + int(bg.B)) / 2), 255}
+			}
+			if c.Style.withConceal {
+				continue
+			}
+			dot := func(px int, py int) {
+				draw.Draw(img, image.Rect(ox+px*sc, oy+py*sc, ox+(px+1)*sc, oy+(py+1)*sc), image.NewUniform(fg), image.Point{}, draw.Src)
+			}
+			if cg := cellGlyph(c.Rune); cg != nil {
+				for py, row := range cg {
+					for px, on := range row {
+						if on {
+							dot(px, py)
+						}
+					}
+				}
+			} else if c.Rune != ' ' && c.Rune != 0 {
+				g := glyph(c.Rune)
+				for gx, bits := range g {
+					for gy := 0; gy < 8; gy++ {
+						if bits&(1<<uint(gy)) == 0 {
+							continue
+						}
+						dot(gx, gy+1)
+						if c.Style.withBold {
+							dot(gx+1, gy+1)
+						}
+					}
+				}
+			}
+			for px := 0; px < _cellWidth; px++ {
+				if c.Style.withUnderLine {
+					dot(px, _cellHeight-1)
+				}
+				if c.Style.withDelete {
+					dot(px, 4)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// toPaletted converts image to paletted image. colors are kept if they are less than 256.
+func toPaletted(img *image.RGBA) *image.Paletted {
+	p := color.Palette{}
+	seen := map[color.RGBA]bool{}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y && p != nil; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			if seen[c] {
+				continue
+			}
+			if len(p) == 256 {
+				p = nil
+				break
+			}
+			seen[c] = true
+			p = append(p, c)
+		}
+	}
+	if p == nil {
+		p = palette.Plan9
+	}
+	pi := image.NewPaletted(b, p)
+	draw.Draw(pi, b, img, b.Min, draw.Src)
+	return pi
+}
@@ -0,0 +1,87 @@
+package ansistrings_test
+
+import (
+	"image/color"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestImage(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("I").Red().Str(" ").BgColor(s.Blue)
+	opt := s.NewImageOptions()
+	opt.Columns = 2
+	opt.Scale = 1
+	opt.Padding = 0
+	img := v.Image(opt)
+
+	if b := img.Bounds(); b.Dx() != 12 || b.Dy() != 10 {
+		t.Errorf("Get %v, want 12x10", b)
+	}
+	tests := []struct {
+		x, y int
+		c    color.RGBA
+	}{
+		{2, 1, s.DefaultTheme.Palette[1]},
+		{0, 1, s.DefaultTheme.Background},
+		{8, 5, s.DefaultTheme.Palette[4]},
+	}
+	for _, tt := range tests {
+		if c := color.RGBAModel.Convert(img.At(tt.x, tt.y)); c != tt.c {
+			t.Errorf("Get %v at (%d, %d), want %v", c, tt.x, tt.y, tt.c)
+		}
+	}
+}
+
+func TestGIF(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("1").Pause(500).Str("2").Pause(200).Str("3")
+	opt := s.NewImageOptions()
+	opt.Columns = 3
+	g := v.GIF(opt)
+	if len(g.Image) != 3 {
+		t.Fatalf("Get %d frames, want 3", len(g.Image))
+	}
+	a := []int{50, 20, 0}
+	for i := range a {
+		if g.Delay[i] != a[i] {
+			t.Errorf("Get %v, want %v", g.Delay, a)
+			break
+		}
+	}
+
+	// profile is used as same as String
+	v = s.NewANSIStrings()
+	v.Str("a").Link("https://example.com/").SetProfile(s.Profile{Hyperlink: true})
+	g = v.GIF(opt)
+	plain := s.NewANSIStrings()
+	if b, a := g.Image[0].Bounds(), plain.Str("a").Image(opt).Bounds(); b != a {
+		t.Errorf("Get %v, want %v", b, a)
+	}
+}
+
+func TestImageBoxDrawing(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Str("─█┼")
+	opt := s.NewImageOptions()
+	opt.Columns = 3
+	opt.Scale = 1
+	opt.Padding = 0
+	img := v.Image(opt)
+	fg, bg := s.DefaultTheme.Foreground, s.DefaultTheme.Background
+	tests := []struct {
+		x, y int
+		c    color.RGBA
+	}{
+		// lines reach edges of cell to connect with next cell
+		{0, 4, fg}, {5, 4, fg}, {0, 0, bg},
+		{6, 0, fg}, {11, 9, fg},
+		{12, 4, fg}, {14, 0, fg}, {14, 9, fg}, {12, 0, bg},
+	}
+	for _, tt := range tests {
+		if c := color.RGBAModel.Convert(img.At(tt.x, tt.y)); c != tt.c {
+			t.Errorf("Get %v at (%d, %d), want %v", c, tt.x, tt.y, tt.c)
+		}
+	}
+}
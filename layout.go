@@ -0,0 +1,165 @@
+package ansistrings
+
+// VAlign is vertical alignment
+type VAlign int
+
+// constant value of vertical alignments
+const (
+	AlignTop VAlign = iota
+	AlignMiddle
+	AlignBottom
+)
+
+// Block is content in Layout with its width setting
+type Block struct {
+	// Content can be ANSIStrings, ANSIString, string or any value
+	Content interface{}
+	// Width is fixed width of block. Lines wider than it are truncated.
+	Width int
+	// Flex is ratio to share rest of Layout's Width among flexible blocks. Block is not flexible if it is 0.
+	Flex int
+	// Align is alignment of lines in block
+	Align Align
+}
+
+// Layout joins multi-line blocks horizontally or vertically.
+// Blocks can be Block or any content which Block accepts.
+type Layout struct {
+	// Width is total width. Flexible blocks share rest of width in Horizontal.
+	// Blocks are aligned in this width in Vertical.
+	Width int
+	// Gap is number of spaces between blocks in Horizontal and number of lines in Vertical
+	Gap int
+	// Align is alignment of blocks in Vertical
+	Align Align
+	// VAlign is alignment of blocks in Horizontal
+	VAlign VAlign
+}
+
+// NewLayout returns new Layout
+func NewLayout() *Layout {
+	return &Layout{}
+}
+
+type layoutBlock struct {
+	Block
+	lines []styledLine
+	width int
+}
+
+func toBlocks(blocks []interface{}) []layoutBlock {
+	bs := make([]layoutBlock, len(blocks))
+	for i, b := range blocks {
+		block, ok := b.(Block)
+		if !ok {
+			block = Block{Content: b}
+		}
+		bs[i] = layoutBlock{Block: block, lines: toLines(block.Content)}
+		bs[i].width = block.Width
+		if block.Width <= 0 {
+			for _, l := range bs[i].lines {
+				if l.width() > bs[i].width {
+					bs[i].width = l.width()
+				}
+			}
+		}
+	}
+	return bs
+}
+
+// line returns y-th line of block fitted to width
+func (b layoutBlock) line(y int, width int, align Align) styledLine {
+	l := styledLine{}
+	if y >= 0 && y < len(b.lines) {
+		l = b.lines[y]
+	}
+	if l.width() > width {
+		l = l.truncate(width, "…")
+	}
+	return l.pad(width, align, ANSIStyle{})
+}
+
+// Horizontal joins blocks side by side
+func (l *Layout) Horizontal(blocks ...interface{}) *ANSIStrings {
+	bs := toBlocks(blocks)
+	flex := 0
+	rest := l.Width - l.Gap*(len(bs)-1)
+	for _, b := range bs {
+		if b.Flex > 0 {
+			flex += b.Flex
+		} else {
+			rest -= b.width
+		}
+	}
+	if l.Width > 0 && flex > 0 {
+		if rest < 0 {
+			rest = 0
+		}
+		last := -1
+		shared := 0
+		for i := range bs {
+			if bs[i].Flex > 0 {
+				bs[i].width = rest * bs[i].Flex / flex
+				shared += bs[i].width
+				last = i
+			}
+		}
+		// remainder of division is given to last flexible block
+		bs[last].width += rest - shared
+	}
+
+	height := 0
+	for _, b := range bs {
+		if len(b.lines) > height {
+			height = len(b.lines)
+		}
+	}
+	lines := make([]styledLine, height)
+	for y := range lines {
+		line := styledLine{}
+		for i, b := range bs {
+			if i > 0 {
+				line = line.append(spaces(l.Gap, ANSIStyle{}))
+			}
+			offset := 0
+			switch l.VAlign {
+			case AlignMiddle:
+				offset = (height - len(b.lines)) / 2
+			case AlignBottom:
+				offset = height - len(b.lines)
+			}
+			line = line.append(b.line(y-offset, b.width, b.Align))
+		}
+		lines[y] = line
+	}
+	return joinLines(lines)
+}
+
+// Vertical joins blocks from top to bottom
+func (l *Layout) Vertical(blocks ...interface{}) *ANSIStrings {
+	bs := toBlocks(blocks)
+	width := l.Width
+	if width <= 0 {
+		for _, b := range bs {
+			if b.width > width {
+				width = b.width
+			}
+		}
+	}
+	lines := []styledLine{}
+	for i, b := range bs {
+		if i > 0 {
+			for j := 0; j < l.Gap; j++ {
+				lines = append(lines, spaces(width, ANSIStyle{}))
+			}
+		}
+		if b.width > width {
+			b.width = width
+		}
+		for y := range b.lines {
+			line := b.line(y, b.width, b.Align)
+			lines = append(lines, line.pad(width, l.Align, ANSIStyle{}))
+		}
+	}
+	return joinLines(lines)
+}
@@ -0,0 +1,64 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestLayoutHorizontal(t *testing.T) {
+	left := s.NewANSIStrings()
+	left.Str("cpu").Red().Str("\nmem\ndisk")
+	box := s.NewBox()
+	box.Border = s.BorderASCII
+	l := s.NewLayout()
+	l.Gap = 1
+	l.VAlign = s.AlignMiddle
+	vt := s.NewVT(30, 0)
+	vt.WriteString(l.Horizontal(&left, box.Render("ok")).String())
+	a := "cpu  +----+\nmem  | ok |\ndisk +----+"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	red := s.NewANSIStyle()
+	red.Red()
+	if c := vt.Cell(1, 1); c.Style != red {
+		t.Errorf("Get %#v, want red", c)
+	}
+
+	l = s.NewLayout()
+	l.Width = 20
+	l.VAlign = s.AlignBottom
+	str := l.Horizontal(
+		s.Block{Content: "name", Width: 3},
+		s.Block{Content: "x", Flex: 1, Align: s.AlignCenter},
+		s.Block{Content: "a\nb", Flex: 2, Align: s.AlignRight},
+	).String()
+	a = "                   a\nna…  x             b"
+	if str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
+
+func TestLayoutVertical(t *testing.T) {
+	title := s.NewANSIStrings()
+	title.Str("title").Bold()
+	l := s.NewLayout()
+	l.Gap = 1
+	l.Align = s.AlignCenter
+	str := l.Vertical(&title, "a long line", s.Block{Content: "right", Width: 7, Align: s.AlignRight}).String()
+	a := "   \033[1mtitle\033[0m   \n           \na long line\n           \n    right  "
+	if str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
+
+func TestLayoutWide(t *testing.T) {
+	l := s.NewLayout()
+	l.Width = 12
+	str := l.Horizontal("日本", s.Block{Content: "語", Flex: 1, Align: s.AlignCenter}).String()
+	a := "日本   語   "
+	if str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
@@ -0,0 +1,68 @@
+package ansistrings
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+)
+
+var lineBreak = regexp.MustCompile("(?s)([\r\n]+)")
+
+// Link sets hyperlink(OSC 8) to string. params are like "id=xxx".
+// If Profile doesn't support hyperlink, string is rendered as "text (url)".
+func (s *ANSIStrings) Link(url string, params ...string) *ANSIStrings {
+	s.CurrentStr().Link(url, params...)
+	return s
+}
+
+// Link sets hyperlink(OSC 8) to string. params are like "id=xxx".
+func (s *ANSIStyle) Link(url string, params ...string) *ANSIStyle {
+	s.link.url = Sanitize(url, SanitizeStrip)
+	s.link.params = Sanitize(strings.Join(params, ":"), SanitizeStrip)
+	return s
+}
+
+// UnsetLink unsets hyperlink of string
+func (s *ANSIStyle) UnsetLink() *ANSIStyle {
+	s.link.url = ""
+	s.link.params = ""
+	return s
+}
+
+// linkedStr returns Str with hyperlink
+func (s *ANSIString) linkedStr() string {
+	if s.link.url == "" {
+		return s.Str
+	}
+	if !s.currentProfile().Hyperlink {
+		return linkFallback(s.Str, s.link.url)
+	}
+	open := "\033]8;" + s.linkParams(s.Str) + ";" + s.link.url + "\033\\"
+	closeLink := "\033]8;;\033\\"
+	str := lineBreak.ReplaceAllStringFunc(s.Str, func(b string) string {
+		return closeLink + b + open
+	})
+	return open + str + closeLink
+}
+
+// linkFallback returns text shown instead of hyperlink when it is not supported
+func linkFallback(str string, url string) string {
+	return fmt.Sprintf("%s (%s)", str, url)
+}
+
+// linkParams returns params of link with id generated from url and str if id is not given.
+// same id is required to treat link split by line break as one link.
+func (s ANSIStyle) linkParams(str string) string {
+	params := s.link.params
+	if strings.Contains(":"+params, ":id=") {
+		return params
+	}
+	h := fnv.New32a()
+	h.Write([]byte(s.link.url + "\000" + str))
+	id := fmt.Sprintf("id=%08x", h.Sum32())
+	if params == "" {
+		return id
+	}
+	return params + ":" + id
+}
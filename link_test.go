@@ -0,0 +1,24 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestLink(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.SetProfile(s.Profile{Hyperlink: true})
+	v.Str("go").Link("https://go.dev", "id=1").Str("a\nb").Link("https://example.com").Blue()
+	a := "\033]8;id=1;https://go.dev\033\\go\033]8;;\033\\" +
+		"\033[34m\033]8;id=8cd1aa9c;https://example.com\033\\a\033]8;;\033\\\033[0m\n\033[34m\033]8;id=8cd1aa9c;https://example.com\033\\b\033]8;;\033\\\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	v.SetProfile(s.Profile{})
+	a = "go (https://go.dev)\033[34ma\033[0m\n\033[34mb (https://example.com)\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+}
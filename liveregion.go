@@ -0,0 +1,119 @@
+package ansistrings
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// LiveRegion re-renders content in place at bottom of output.
+// Log written to LiveRegion is printed above the content.
+type LiveRegion struct {
+	w       io.Writer
+	width   int
+	content string
+	// rows is number of rows of content on terminal including wrapped rows
+	rows int
+	log  []byte
+	mu   sync.Mutex
+}
+
+// NewLiveRegion returns new LiveRegion. width is width of terminal to count wrapped rows.
+func NewLiveRegion(w io.Writer, width int) *LiveRegion {
+	if width < 1 {
+		panic("width should be greater than 0")
+	}
+	return &LiveRegion{w: w, width: width}
+}
+
+// Resize changes width of terminal
+func (l *LiveRegion) Resize(width int) *LiveRegion {
+	if width < 1 {
+		panic("width should be greater than 0")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.width = width
+	return l
+}
+
+// Update replaces content with given ANSIStrings
+func (l *LiveRegion) Update(content *ANSIStrings) *LiveRegion {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v := NewANSIStrings()
+	l.erase(&v)
+	l.content = strings.TrimSuffix(content.String(), "\n")
+	l.draw(&v)
+	v.Fprint(l.w)
+	return l
+}
+
+// Write writes log above content. incomplete last line is kept until line break is written.
+func (l *LiveRegion) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.log = append(l.log, p...)
+	i := bytes.LastIndexByte(l.log, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+	v := NewANSIStrings()
+	l.erase(&v)
+	v.Str(string(l.log[:i+1]))
+	l.log = l.log[i+1:]
+	l.draw(&v)
+	v.Fprint(l.w)
+	return len(p), nil
+}
+
+// Clear erases content from terminal
+func (l *LiveRegion) Clear() *LiveRegion {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v := NewANSIStrings()
+	l.erase(&v)
+	l.content = ""
+	v.Fprint(l.w)
+	return l
+}
+
+// Close leaves last content on terminal and flushes incomplete log line
+func (l *LiveRegion) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.log) > 0 {
+		v := NewANSIStrings()
+		l.erase(&v)
+		v.Str(string(l.log) + "\n")
+		l.log = nil
+		l.draw(&v)
+		v.Fprint(l.w)
+	}
+	l.rows = 0
+}
+
+// erase moves cursor to first row of content and erases below
+func (l *LiveRegion) erase(v *ANSIStrings) {
+	v.Str("\r")
+	if l.rows > 0 {
+		v.Up(l.rows).EraseBelow()
+	}
+	l.rows = 0
+}
+
+// draw draws content and leaves cursor at start of next row
+func (l *LiveRegion) draw(v *ANSIStrings) {
+	if l.content == "" {
+		return
+	}
+	for _, line := range strings.Split(l.content, "\n") {
+		n := (VisibleWidth(line) + l.width - 1) / l.width
+		if n < 1 {
+			n = 1
+		}
+		l.rows += n
+	}
+	v.Str(l.content + "\n")
+}
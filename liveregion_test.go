@@ -0,0 +1,58 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestLiveRegion(t *testing.T) {
+	vt := s.NewVT(10, 0)
+	l := s.NewLiveRegion(vt, 10)
+
+	v := s.NewANSIStrings()
+	l.Update(v.Str("a: 0%\nb: 0%\nc: 0%"))
+	fmt.Fprint(l, "log")
+	fmt.Fprint(l, " 1\n")
+	v = s.NewANSIStrings()
+	l.Update(v.Str("a: done"))
+	a := "log 1\na: done"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+
+	// wrapped line
+	v = s.NewANSIStrings()
+	l.Update(v.Str("0123456789abcde").Bold().Str("\nz"))
+	fmt.Fprint(l, "log 2\n")
+	v = s.NewANSIStrings()
+	l.Update(v.Str("ok"))
+	a = "log 1\nlog 2\nok"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+
+	fmt.Fprint(l, "log 3")
+	l.Close()
+	fmt.Fprint(vt, "after\n")
+	a = "log 1\nlog 2\nlog 3\nok\nafter"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+}
+
+func TestLiveRegionWide(t *testing.T) {
+	b := &bytes.Buffer{}
+	l := s.NewLiveRegion(b, 6)
+	v := s.NewANSIStrings()
+	// 10 columns are wrapped into 2 rows
+	l.Update(v.Str("あいうえお\nok"))
+	b.Reset()
+	l.Clear()
+	if !strings.HasPrefix(b.String(), "\r\033[3A") {
+		t.Errorf("Get %#v, want to move up 3 rows", b.String())
+	}
+}
@@ -0,0 +1,103 @@
+package ansistrings
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// MultiProgress draws multiple Progress as live block at bottom of output.
+// It is safe to use from multiple goroutines. Log written to MultiProgress is printed above bars.
+type MultiProgress struct {
+	// Interval is minimum interval to redraw bars
+	Interval time.Duration
+	// Terminal is whether output is terminal. It is detected by NewMultiProgress.
+	Terminal bool
+
+	w      io.Writer
+	live   *LiveRegion
+	bars   []*Progress
+	drawn  time.Time
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewMultiProgress returns new MultiProgress. width is width of terminal.
+func NewMultiProgress(w io.Writer, width int) *MultiProgress {
+	return &MultiProgress{
+		Interval: 100 * time.Millisecond,
+		w:        w,
+		Terminal: IsTerminal(w),
+		live:     NewLiveRegion(w, width),
+	}
+}
+
+// Add adds new Progress whose value reaches total
+func (m *MultiProgress) Add(total int64) *Progress {
+	p := NewProgress(m.w, total)
+	p.Terminal = m.Terminal
+	p.parent = m
+	m.mu.Lock()
+	m.bars = append(m.bars, p)
+	m.mu.Unlock()
+	m.redraw(true)
+	return p
+}
+
+// Remove removes Progress from MultiProgress
+func (m *MultiProgress) Remove(p *Progress) {
+	m.mu.Lock()
+	for i, b := range m.bars {
+		if b == p {
+			m.bars = append(m.bars[:i], m.bars[i+1:]...)
+			break
+		}
+	}
+	m.mu.Unlock()
+	m.redraw(true)
+}
+
+// Write writes log above bars
+func (m *MultiProgress) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.Terminal || m.closed {
+		return m.w.Write(p)
+	}
+	return m.live.Write(p)
+}
+
+// Close draws bars at last and leaves them on terminal
+func (m *MultiProgress) Close() {
+	m.redraw(true)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Terminal && !m.closed {
+		m.live.Close()
+	}
+	m.closed = true
+}
+
+// redraw draws all bars. it is skipped in Interval unless force is true.
+func (m *MultiProgress) redraw(force bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !m.Terminal || m.closed {
+		return
+	}
+	now := time.Now()
+	if !force && now.Sub(m.drawn) < m.Interval {
+		return
+	}
+	m.drawn = now
+	v := NewANSIStrings()
+	for i, p := range m.bars {
+		if i > 0 {
+			v.Str("\n")
+		}
+		p.mu.Lock()
+		p.render(&v)
+		p.mu.Unlock()
+	}
+	m.live.Update(&v)
+}
@@ -0,0 +1,85 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestMultiProgress(t *testing.T) {
+	vt := s.NewVT(30, 0)
+	m := s.NewMultiProgress(vt, 30)
+	m.Terminal = true
+	m.Interval = 0
+
+	wg := sync.WaitGroup{}
+	bars := []*s.Progress{}
+	for i := 1; i <= 3; i++ {
+		p := m.Add(100)
+		p.Title = fmt.Sprintf("file%d", i)
+		p.Width = 10
+		p.Fill, p.Empty = '#', '-'
+		bars = append(bars, p)
+	}
+	for i, p := range bars {
+		wg.Add(1)
+		go func(i int, p *s.Progress) {
+			defer wg.Done()
+			for j := 0; j < 10*(i+1); j++ {
+				p.Add(1)
+			}
+			fmt.Fprintf(m, "file%d done\n", i+1)
+		}(i, p)
+	}
+	wg.Wait()
+	m.Remove(bars[1])
+	m.Close()
+
+	// order of logs depends on goroutines
+	text := vt.Text()
+	a := "file1 #---------  10%\nfile3 ###-------  30%"
+	if !strings.HasSuffix(text, a) {
+		t.Errorf("Get %#v, want bars at last", text)
+	}
+	for i := 1; i <= 3; i++ {
+		if !strings.Contains(text, fmt.Sprintf("file%d done\n", i)) {
+			t.Errorf("Get %#v, want log of file%d", text, i)
+		}
+	}
+	if n := strings.Count(text, "\n"); n != 4 {
+		t.Errorf("Get %d lines, want 5 lines", n+1)
+	}
+}
+
+func TestMultiProgressNotTerminal(t *testing.T) {
+	b := &bytes.Buffer{}
+	m := s.NewMultiProgress(b, 30)
+	wg := sync.WaitGroup{}
+	for i := 1; i <= 3; i++ {
+		p := m.Add(10)
+		p.Title = fmt.Sprintf("file%d", i)
+		p.Interval = 0
+		wg.Add(1)
+		go func(i int, p *s.Progress) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				p.Add(1)
+				fmt.Fprintf(m, "log%d\n", i)
+			}
+		}(i, p)
+	}
+	wg.Wait()
+	m.Close()
+	for _, line := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		if !strings.HasPrefix(line, "log") && !strings.HasPrefix(line, "file") {
+			t.Errorf("Get %#v, want log or progress line", line)
+		}
+	}
+	if n := strings.Count(b.String(), "\n"); n != 60 {
+		t.Errorf("Get %d lines, want 60", n)
+	}
+}
@@ -0,0 +1,111 @@
+package ansistrings
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Multiplexer is terminal multiplexer which escape sequences should be passed through
+type Multiplexer string
+
+// constant value of multiplexers
+const (
+	MultiplexerNone   Multiplexer = ""
+	MultiplexerTmux   Multiplexer = "tmux"
+	MultiplexerScreen Multiplexer = "screen"
+)
+
+// Profile is set of capabilities of terminal
+type Profile struct {
+	// Hyperlink is whether OSC 8 hyperlink is supported
+	Hyperlink bool
+	// Multiplexer is terminal multiplexer running in terminal
+	Multiplexer Multiplexer
+	// LightBackground is whether background of terminal is light
+	LightBackground bool
+	// SyncOutput is whether synchronized update(mode 2026) is supported
+	SyncOutput bool
+}
+
+// DefaultProfile is Profile used by ANSIStrings which has no Profile set
+var DefaultProfile = DetectProfile()
+
+// DetectProfile detects capabilities of terminal from environment variables
+func DetectProfile() Profile {
+	return detectProfile(os.Getenv)
+}
+
+func detectProfile(getenv func(string) string) Profile {
+	p := Profile{}
+	p.LightBackground, _ = lightBackgroundFromEnv(getenv)
+	term := getenv("TERM")
+	if getenv("TMUX") != "" {
+		p.Multiplexer = MultiplexerTmux
+	} else if getenv("STY") != "" || strings.HasPrefix(term, "screen") {
+		p.Multiplexer = MultiplexerScreen
+	}
+	if term == "dumb" {
+		return p
+	}
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "ghostty":
+		p.Hyperlink = true
+		p.SyncOutput = true
+	case "vscode", "Hyper":
+		p.Hyperlink = true
+	}
+	if v, err := strconv.Atoi(getenv("VTE_VERSION")); err == nil && v >= 5000 {
+		p.Hyperlink = true
+		// VTE supports synchronized update since 0.68
+		p.SyncOutput = v >= 6800
+	}
+	if getenv("WT_SESSION") != "" || getenv("KITTY_WINDOW_ID") != "" {
+		p.Hyperlink = true
+		p.SyncOutput = true
+	}
+	for _, t := range []string{"kitty", "alacritty", "foot", "ghostty", "wezterm"} {
+		if strings.Contains(term, t) {
+			p.Hyperlink = true
+			p.SyncOutput = true
+		}
+	}
+	return p
+}
+
+// SetProfile sets Profile to render ANSIStrings
+func (s *ANSIStrings) SetProfile(p Profile) *ANSIStrings {
+	s.profile = &p
+	return s
+}
+
+// SetProfile sets Profile to render ANSIString
+func (s *ANSIString) SetProfile(p Profile) *ANSIString {
+	s.profile = &p
+	return s
+}
+
+func (s *ANSIStrings) currentProfile() Profile {
+	if s.profile != nil {
+		return *s.profile
+	}
+	return DefaultProfile
+}
+
+func (s *ANSIString) currentProfile() Profile {
+	if s.profile != nil {
+		return *s.profile
+	}
+	return DefaultProfile
+}
+
+// wrap wraps escape sequence to pass it through multiplexer to outer terminal
+func (m Multiplexer) wrap(seq string) string {
+	switch m {
+	case MultiplexerTmux:
+		return "\033Ptmux;" + strings.ReplaceAll(seq, "\033", "\033\033") + "\033\\"
+	case MultiplexerScreen:
+		return "\033P" + seq + "\033\\"
+	}
+	return seq
+}
@@ -0,0 +1,248 @@
+package ansistrings
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// eighths are blocks to draw partial cell of progress bar
+var eighths = []rune(" ▏▎▍▌▋▊▉")
+
+// Progress is progress bar.
+// When output is not terminal, plain progress line is printed at each Interval instead of bar.
+type Progress struct {
+	// Title is printed before bar
+	Title string
+	// Width is number of cells of bar
+	Width int
+	// Fill and Empty are characters of filled and empty part. Fill is ignored when Eighths is true.
+	Fill  rune
+	Empty rune
+	// Eighths draws partial cell with eighth blocks for sub-character precision
+	Eighths bool
+	// Style and EmptyStyle are style of filled and empty part
+	Style      ANSIStyle
+	EmptyStyle ANSIStyle
+	// Gradient is colors of filled part from left to right. Style's color is used if it is empty.
+	Gradient []color.RGBA
+	// ShowPercent, ShowRate and ShowETA show labels after bar
+	ShowPercent bool
+	ShowRate    bool
+	ShowETA     bool
+	// Unit is unit of rate label
+	Unit string
+	// Interval is interval to print plain line when output is not terminal
+	Interval time.Duration
+	// RedrawInterval is minimum interval to redraw bar when output is terminal
+	RedrawInterval time.Duration
+	// Terminal is whether output is terminal. It is detected by NewProgress.
+	Terminal bool
+
+	w       io.Writer
+	total   int64
+	current int64
+	start   time.Time
+	printed time.Time
+	mu      sync.Mutex
+	parent  *MultiProgress
+}
+
+// NewProgress returns new Progress whose value reaches total
+func NewProgress(w io.Writer, total int64) *Progress {
+	return &Progress{
+		Width:          40,
+		Fill:           '█',
+		Empty:          '░',
+		ShowPercent:    true,
+		Interval:       5 * time.Second,
+		RedrawInterval: 100 * time.Millisecond,
+		w:              w,
+		Terminal:       IsTerminal(w),
+		total:          total,
+		start:          time.Now(),
+	}
+}
+
+// Set sets current value and redraws Progress
+func (p *Progress) Set(n int64) *Progress {
+	p.mu.Lock()
+	p.current = n
+	p.mu.Unlock()
+	p.refresh(false)
+	return p
+}
+
+// Add adds n to current value and redraws Progress
+func (p *Progress) Add(n int64) *Progress {
+	p.mu.Lock()
+	p.current += n
+	p.mu.Unlock()
+	p.refresh(false)
+	return p
+}
+
+// Write adds length of b to current value. Progress can be used with io.TeeReader.
+func (p *Progress) Write(b []byte) (int, error) {
+	p.Add(int64(len(b)))
+	return len(b), nil
+}
+
+// Done draws Progress at last and breaks line
+func (p *Progress) Done() {
+	p.refresh(true)
+	if p.Terminal && p.parent == nil {
+		io.WriteString(p.w, "\n")
+	}
+}
+
+// refresh redraws Progress or MultiProgress which has it
+func (p *Progress) refresh(done bool) {
+	if p.parent != nil && p.Terminal {
+		p.parent.redraw(done)
+		return
+	} else if p.parent != nil {
+		// plain line is written with log of MultiProgress
+		p.parent.mu.Lock()
+		defer p.parent.mu.Unlock()
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.print(done)
+}
+
+func (p *Progress) print(done bool) {
+	interval := p.Interval
+	if p.Terminal {
+		interval = p.RedrawInterval
+	}
+	now := time.Now()
+	if !done && !p.printed.IsZero() && now.Sub(p.printed) < interval {
+		return
+	}
+	p.printed = now
+	if p.Terminal {
+		v := NewANSIStrings()
+		v.Str("\r")
+		p.render(&v)
+		v.EraseLineToEnd().Fprint(p.w)
+		return
+	}
+	line := fmt.Sprintf("%d/%d", p.current, p.total)
+	if p.Title != "" {
+		line = p.Title + " " + line
+	}
+	if labels := p.labels(now); labels != "" {
+		line += " " + labels
+	}
+	io.WriteString(p.w, line+"\n")
+}
+
+// Render returns ANSIStrings of title, bar and labels
+func (p *Progress) Render() *ANSIStrings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := NewANSIStrings()
+	p.render(&v)
+	return &v
+}
+
+func (p *Progress) render(v *ANSIStrings) {
+	if p.Title != "" {
+		v.Str(p.Title + " ")
+	}
+	p.bar(v)
+	if labels := p.labels(time.Now()); labels != "" {
+		v.Str(" " + labels)
+	}
+}
+
+func (p *Progress) ratio() float64 {
+	if p.total <= 0 {
+		return 0
+	}
+	r := float64(p.current) / float64(p.total)
+	if r < 0 {
+		return 0
+	} else if r > 1 {
+		return 1
+	}
+	return r
+}
+
+func (p *Progress) bar(v *ANSIStrings) {
+	full, partial := 0, 0
+	if p.Eighths {
+		n := int(p.ratio() * float64(p.Width*8))
+		full, partial = n/8, n%8
+	} else {
+		full = int(p.ratio() * float64(p.Width))
+	}
+	fill := string(p.Fill)
+	if p.Eighths {
+		fill = "█"
+	}
+	for i := 0; i < full; i++ {
+		p.fillCell(v, fill, i)
+	}
+	cells := full
+	if partial > 0 {
+		p.fillCell(v, string(eighths[partial]), full)
+		cells++
+	}
+	if cells < p.Width {
+		v.Str(strings.Repeat(string(p.Empty), p.Width-cells)).Style(p.EmptyStyle)
+	}
+}
+
+// fillCell adds filled cell at i-th position of bar
+func (p *Progress) fillCell(v *ANSIStrings, str string, i int) {
+	v.Str(str).Style(p.Style)
+	if len(p.Gradient) == 0 {
+		return
+	}
+	c := p.Gradient[0]
+	if len(p.Gradient) > 1 && p.Width > 1 {
+		pos := float64(i) / float64(p.Width-1) * float64(len(p.Gradient)-1)
+		from := int(pos)
+		if from >= len(p.Gradient)-1 {
+			from = len(p.Gradient) - 2
+		}
+		c = blend(p.Gradient[from], p.Gradient[from+1], pos-float64(from))
+	}
+	v.RGB(int(c.R), int(c.G), int(c.B))
+}
+
+// blend returns color between a and b. t is from 0(a) to 1(b).
+func blend(a color.RGBA, b color.RGBA, t float64) color.RGBA {
+	mix := func(x uint8, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
+
+func (p *Progress) labels(now time.Time) string {
+	labels := []string{}
+	if p.ShowPercent {
+		labels = append(labels, fmt.Sprintf("%3d%%", int(p.ratio()*100)))
+	}
+	elapsed := now.Sub(p.start).Seconds()
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(p.current) / elapsed
+	}
+	if p.ShowRate {
+		labels = append(labels, fmt.Sprintf("%.1f%s/s", rate, p.Unit))
+	}
+	if p.ShowETA {
+		eta := "--"
+		if rate > 0 && p.current <= p.total {
+			eta = time.Duration(float64(p.total-p.current) / rate * float64(time.Second)).Round(time.Second).String()
+		}
+		labels = append(labels, "ETA "+eta)
+	}
+	return strings.Join(labels, " ")
+}
@@ -0,0 +1,76 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestProgress(t *testing.T) {
+	b := &bytes.Buffer{}
+	p := s.NewProgress(b, 200)
+	p.Width = 10
+	p.Fill = '#'
+	p.Empty = '-'
+	p.Title = "dl"
+	p.Set(100)
+	if str := p.Render().String(); str != "dl #####-----  50%" {
+		t.Errorf("Get %#v, want %#v", str, "dl #####-----  50%")
+	}
+
+	p.Eighths = true
+	p.Empty = ' '
+	p.ShowPercent = false
+	p.Set(31)
+	if str := p.Render().String(); str != "dl █▌        " {
+		t.Errorf("Get %#v, want %#v", str, "dl █▌        ")
+	}
+
+	p = s.NewProgress(b, 2)
+	p.Width = 2
+	p.ShowPercent = false
+	p.Gradient = []color.RGBA{{255, 0, 0, 255}, {0, 0, 255, 255}}
+	p.Set(2)
+	a := "\033[38;2;255;0;0m█\033[0m\033[38;2;0;0;255m█\033[0m"
+	if str := p.Render().String(); str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
+
+func TestProgressRedrawInterval(t *testing.T) {
+	b := &bytes.Buffer{}
+	p := s.NewProgress(b, 100)
+	p.Terminal = true
+	p.RedrawInterval = time.Hour
+	for i := 0; i < 100; i++ {
+		p.Add(1)
+	}
+	p.Done()
+	if n := strings.Count(b.String(), "\r"); n != 2 {
+		t.Errorf("Get %d draws, want 2", n)
+	}
+	vt := s.NewVT(80, 0)
+	vt.Write(b.Bytes())
+	if !strings.HasSuffix(vt.Text(), "100%") {
+		t.Errorf("Get %#v, want last state", vt.Text())
+	}
+}
+
+func TestProgressNotTerminal(t *testing.T) {
+	b := &bytes.Buffer{}
+	p := s.NewProgress(b, 100)
+	p.Interval = time.Hour
+	p.ShowETA = true
+	for i := 0; i < 100; i++ {
+		p.Add(1)
+	}
+	p.Done()
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "1/100   1% ETA ") || lines[1] != "100/100 100% ETA 0s" {
+		t.Errorf("Get %#v, want 2 plain lines", lines)
+	}
+}
@@ -0,0 +1,260 @@
+package ansistrings
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queries to terminal. terminal should be in raw mode to read responses.
+const (
+	// QueryCursorPosition is DSR to ask cursor position
+	QueryCursorPosition = "\033[6n"
+	// QueryDeviceAttributes is DA1 to ask primary device attributes
+	QueryDeviceAttributes = "\033[c"
+	// QuerySecondaryDeviceAttributes is DA2 to ask terminal type and version
+	QuerySecondaryDeviceAttributes = "\033[>c"
+	// QueryTerminalVersion is XTVERSION to ask name and version of terminal
+	QueryTerminalVersion = "\033[>0q"
+	// QueryForeground is OSC 10 to ask foreground color
+	QueryForeground = "\033]10;?\033\\"
+	// QueryBackground is OSC 11 to ask background color
+	QueryBackground = "\033]11;?\033\\"
+)
+
+// ResponseKind is kind of terminal response
+type ResponseKind int
+
+// constant value of response kinds
+const (
+	ResponseUnknown ResponseKind = iota
+	ResponseCursorPosition
+	ResponseDeviceAttributes
+	ResponseSecondaryDeviceAttributes
+	ResponseTerminalVersion
+	ResponseForeground
+	ResponseBackground
+)
+
+// Response is response of terminal for query
+type Response struct {
+	Kind ResponseKind
+	Raw  string
+	// X and Y are cursor position(1 origin) of ResponseCursorPosition
+	X int
+	Y int
+	// Params are parameters of ResponseDeviceAttributes and ResponseSecondaryDeviceAttributes
+	Params []int
+	// Version is name and version of terminal of ResponseTerminalVersion
+	Version string
+	// Color is color of ResponseForeground and ResponseBackground
+	Color color.RGBA
+}
+
+// ErrTimeout is returned when terminal doesn't respond in time
+var ErrTimeout = errors.New("timeout to read terminal response")
+
+// ErrReaderClosed is returned when ResponseReader is closed
+var ErrReaderClosed = errors.New("response reader is closed")
+
+// ResponseReader reads terminal responses from io.Reader(e.g. os.Stdin).
+// It reads only while Read is waiting not to take input after responses.
+// Terminal should be in raw mode because terminal in cooked mode doesn't pass response until Enter.
+type ResponseReader struct {
+	r    io.Reader
+	once sync.Once
+	req  chan struct{}
+	ch   chan readResult
+	done chan struct{}
+	// pending is whether reading is requested and its result is not received
+	pending   bool
+	closeOnce sync.Once
+	err       error
+	buf       []byte
+}
+
+type readResult struct {
+	b   []byte
+	err error
+}
+
+// NewResponseReader returns new ResponseReader
+func NewResponseReader(r io.Reader) *ResponseReader {
+	return &ResponseReader{r: r, req: make(chan struct{}), ch: make(chan readResult, 1), done: make(chan struct{})}
+}
+
+func (rr *ResponseReader) start() {
+	go func() {
+		b := make([]byte, 256)
+		for {
+			select {
+			case <-rr.req:
+			case <-rr.done:
+				return
+			}
+			n, err := rr.r.Read(b)
+			rr.ch <- readResult{b: append([]byte{}, b[:n]...), err: err}
+			if err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// Read reads next response in timeout. bytes which are not response are discarded.
+func (rr *ResponseReader) Read(timeout time.Duration) (Response, error) {
+	rr.once.Do(rr.start)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		if res, n, ok := parseResponse(rr.buf); ok {
+			rr.buf = rr.buf[n:]
+			return res, nil
+		}
+		if rr.err != nil {
+			return Response{}, rr.err
+		}
+		if !rr.pending {
+			select {
+			case rr.req <- struct{}{}:
+				rr.pending = true
+			case <-rr.done:
+				return Response{}, ErrReaderClosed
+			}
+		}
+		select {
+		case res := <-rr.ch:
+			rr.pending = false
+			rr.buf = append(rr.buf, res.b...)
+			rr.err = res.err
+		case <-timer.C:
+			return Response{}, ErrTimeout
+		case <-rr.done:
+			return Response{}, ErrReaderClosed
+		}
+	}
+}
+
+// Close stops reading. Reading which is already waiting for input ends when input comes.
+// It doesn't close underlying io.Reader.
+func (rr *ResponseReader) Close() error {
+	rr.closeOnce.Do(func() {
+		close(rr.done)
+	})
+	return nil
+}
+
+// Query writes query to w and reads response in timeout
+func (rr *ResponseReader) Query(w io.Writer, query string, timeout time.Duration) (Response, error) {
+	if _, err := io.WriteString(w, query); err != nil {
+		return Response{}, err
+	}
+	return rr.Read(timeout)
+}
+
+// parseResponse parses first response in b. n is bytes consumed. ok is false if response is not completed.
+func parseResponse(b []byte) (res Response, n int, ok bool) {
+	start := bytes.IndexByte(b, '\033')
+	if start < 0 || start+1 >= len(b) {
+		return res, 0, false
+	}
+	b = b[start:]
+	end := -1
+	body := ""
+	switch b[1] {
+	case '[':
+		for i := 2; i < len(b); i++ {
+			if b[i] >= 0x40 && b[i] <= 0x7e {
+				end = i + 1
+				break
+			}
+		}
+		if end < 0 {
+			return res, 0, false
+		}
+		body = string(b[2:end])
+	case ']', 'P':
+		for i := 2; i < len(b); i++ {
+			if b[i] == '\a' {
+				end = i + 1
+				body = string(b[2:i])
+				break
+			}
+			if b[i] == '\033' && i+1 < len(b) && b[i+1] == '\\' {
+				end = i + 2
+				body = string(b[2:i])
+				break
+			}
+		}
+		if end < 0 {
+			return res, 0, false
+		}
+	default:
+		end = 2
+	}
+	res.Raw = string(b[:end])
+	n = start + end
+
+	switch {
+	case b[1] == '[' && strings.HasSuffix(body, "R"):
+		p := parseParams(body)
+		if len(p) == 2 {
+			res.Kind = ResponseCursorPosition
+			res.Y, res.X = p[0], p[1]
+		}
+	case b[1] == '[' && strings.HasPrefix(body, "?") && strings.HasSuffix(body, "c"):
+		res.Kind = ResponseDeviceAttributes
+		res.Params = parseParams(body)
+	case b[1] == '[' && strings.HasPrefix(body, ">") && strings.HasSuffix(body, "c"):
+		res.Kind = ResponseSecondaryDeviceAttributes
+		res.Params = parseParams(body)
+	case b[1] == 'P' && strings.HasPrefix(body, ">|"):
+		res.Kind = ResponseTerminalVersion
+		res.Version = body[2:]
+	case b[1] == ']' && (strings.HasPrefix(body, "10;") || strings.HasPrefix(body, "11;")):
+		if c, ok := parseXColor(body[3:]); ok {
+			res.Kind = ResponseForeground
+			if body[1] == '1' {
+				res.Kind = ResponseBackground
+			}
+			res.Color = c
+		}
+	}
+	return res, n, true
+}
+
+// parseXColor parses color like "rgb:ffff/0000/8080"
+func parseXColor(s string) (color.RGBA, bool) {
+	if !strings.HasPrefix(s, "rgb:") {
+		return color.RGBA{}, false
+	}
+	parts := strings.Split(s[4:], "/")
+	if len(parts) != 3 {
+		return color.RGBA{}, false
+	}
+	c := [3]uint8{}
+	for i, p := range parts {
+		if len(p) < 1 || len(p) > 4 {
+			return color.RGBA{}, false
+		}
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil {
+			return color.RGBA{}, false
+		}
+		full := uint64(1)<<(4*uint(len(p))) - 1
+		c[i] = uint8(v * 255 / full)
+	}
+	return color.RGBA{c[0], c[1], c[2], 255}, true
+}
+
+// IsDark returns whether color is dark by its luminance
+func IsDark(c color.Color) bool {
+	r, g, b, _ := c.RGBA()
+	l := 0.2126*float64(r) + 0.7152*float64(g) + 0.0722*float64(b)
+	return l < 0x7fff
+}
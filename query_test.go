@@ -0,0 +1,87 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestResponseReader(t *testing.T) {
+	in := "x\033[12;40R\033[?62;1;4c\033[>1;10;0c\033P>|xterm(367)\033\\" +
+		"\033]11;rgb:ffff/ffff/ffff\033\\\033]10;rgb:00/80/ff\a"
+	rr := s.NewResponseReader(bytes.NewBufferString(in))
+
+	res, err := rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseCursorPosition || res.X != 40 || res.Y != 12 {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	res, err = rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseDeviceAttributes || len(res.Params) != 3 || res.Params[0] != 62 {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	res, err = rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseSecondaryDeviceAttributes || len(res.Params) != 3 || res.Params[1] != 10 {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	res, err = rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseTerminalVersion || res.Version != "xterm(367)" {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	res, err = rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseBackground || res.Color != (color.RGBA{255, 255, 255, 255}) || s.IsDark(res.Color) {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	res, err = rr.Read(time.Second)
+	if err != nil || res.Kind != s.ResponseForeground || res.Color != (color.RGBA{0, 128, 255, 255}) {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	if _, err = rr.Read(time.Second); err != io.EOF {
+		t.Errorf("Get %v, want EOF", err)
+	}
+}
+
+func TestResponseReaderTimeout(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+	rr := s.NewResponseReader(r)
+	b := &bytes.Buffer{}
+	if _, err := rr.Query(b, s.QueryBackground, 10*time.Millisecond); err != s.ErrTimeout {
+		t.Errorf("Get %v, want %v", err, s.ErrTimeout)
+	}
+	if b.String() != s.QueryBackground {
+		t.Errorf("Get %#v, want %#v", b.String(), s.QueryBackground)
+	}
+}
+
+// chunkReader returns a chunk for each Read
+type chunkReader struct {
+	chunks []string
+	reads  int
+}
+
+func (r *chunkReader) Read(b []byte) (int, error) {
+	if r.reads >= len(r.chunks) {
+		return 0, io.EOF
+	}
+	r.reads++
+	return copy(b, r.chunks[r.reads-1]), nil
+}
+
+func TestResponseReaderClose(t *testing.T) {
+	r := &chunkReader{chunks: []string{"\033[1;2R", "keys typed by user"}}
+	rr := s.NewResponseReader(r)
+	if res, err := rr.Read(time.Second); err != nil || res.Kind != s.ResponseCursorPosition {
+		t.Errorf("Get %#v, %v", res, err)
+	}
+	rr.Close()
+	if _, err := rr.Read(time.Second); err != s.ErrReaderClosed {
+		t.Errorf("Get %v, want %v", err, s.ErrReaderClosed)
+	}
+	if r.reads != 1 {
+		t.Errorf("Get %d reads, want 1 not to take input after response", r.reads)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package ansistrings
+
+import "os"
+
+// NotifyResize returns channel which receives new size of terminal of f when terminal is resized.
+// Resize is not notified on this platform. Call stop to unsubscribe.
+func NotifyResize(f *os.File) (ch <-chan Size, stop func()) {
+	sizes := make(chan Size)
+	return sizes, func() {
+		close(sizes)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build unix
+
+package ansistrings
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyResize returns channel which receives new size of terminal of f when terminal is resized(SIGWINCH).
+// Call stop to unsubscribe.
+func NotifyResize(f *os.File) (ch <-chan Size, stop func()) {
+	sizes := make(chan Size, 1)
+	sig := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(sig, syscall.SIGWINCH)
+	go func() {
+		defer close(sizes)
+		for {
+			select {
+			case <-sig:
+				s, err := TerminalSize(f)
+				if err != nil {
+					continue
+				}
+				// drop old size not received yet
+				select {
+				case <-sizes:
+				default:
+				}
+				sizes <- s
+			case <-done:
+				return
+			}
+		}
+	}()
+	return sizes, func() {
+		signal.Stop(sig)
+		close(done)
+	}
+}
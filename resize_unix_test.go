@@ -0,0 +1,27 @@
+//go:build unix
+
+package ansistrings_test
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestNotifyResize(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+	t.Setenv("LINES", "30")
+	ch, stop := s.NotifyResize(nil)
+	defer stop()
+	syscall.Kill(syscall.Getpid(), syscall.SIGWINCH)
+	select {
+	case size := <-ch:
+		if size != (s.Size{Width: 120, Height: 30}) {
+			t.Errorf("Get %#v, want 120x30", size)
+		}
+	case <-time.After(time.Second):
+		t.Error("resize is not notified")
+	}
+}
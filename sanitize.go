@@ -0,0 +1,90 @@
+package ansistrings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SanitizePolicy is policy to sanitize untrusted text
+type SanitizePolicy int
+
+// constant value of sanitize policies
+const (
+	// SanitizeNone keeps text as it is
+	SanitizeNone SanitizePolicy = iota
+	// SanitizeStrip removes escape sequences and control characters except "\n" and "\t"
+	SanitizeStrip
+	// SanitizeEscape shows escape sequences and control characters visibly like "\x1b[2J"
+	SanitizeEscape
+	// SanitizeSGROnly keeps only SGR(color and text style) and removes the others
+	SanitizeSGROnly
+)
+
+// Sanitize returns text sanitized by given policy.
+// Use it for user-controlled data(filenames, log lines, HTTP headers, etc.).
+func Sanitize(str string, policy SanitizePolicy) string {
+	if policy == SanitizeNone {
+		return str
+	}
+	b := strings.Builder{}
+	for _, t := range tokenize(str) {
+		switch {
+		case t.kind == tokText:
+			b.WriteString(sanitizeC1(t.raw, policy))
+		case policy == SanitizeEscape:
+			q := strconv.QuoteToASCII(t.raw)
+			b.WriteString(q[1 : len(q)-1])
+		case policy == SanitizeSGROnly && isSGR(t):
+			b.WriteString(t.raw)
+		}
+	}
+	return b.String()
+}
+
+// SetSanitizePolicy sets policy to sanitize text given to Str
+func (s *ANSIStrings) SetSanitizePolicy(policy SanitizePolicy) *ANSIStrings {
+	s.sanitize = policy
+	return s
+}
+
+// isSGR returns whether token is SGR(e.g. "\033[1;31m")
+func isSGR(t token) bool {
+	if t.kind != tokCSI || t.final != 'm' {
+		return false
+	}
+	for _, c := range t.params {
+		if (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// sanitizeC1 sanitizes C1 control characters(U+0080 - U+009F) which some terminals interpret.
+// raw 8-bit C1 bytes which are not part of UTF-8 character are also sanitized.
+func sanitizeC1(str string, policy SanitizePolicy) string {
+	b := strings.Builder{}
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		raw := str[i : i+size]
+		i += size
+		if r == utf8.RuneError && size == 1 {
+			r = rune(raw[0])
+		}
+		if !isC1(r) {
+			b.WriteString(raw)
+		} else if policy == SanitizeEscape && size == 1 {
+			b.WriteString(fmt.Sprintf("\\x%02x", raw[0]))
+		} else if policy == SanitizeEscape {
+			q := strconv.QuoteToASCII(raw)
+			b.WriteString(q[1 : len(q)-1])
+		}
+	}
+	return b.String()
+}
+
+func isC1(r rune) bool {
+	return r >= 0x80 && r <= 0x9f
+}
@@ -0,0 +1,40 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestSanitize(t *testing.T) {
+	in := "a\033[31mb\033[0m\033[2J\033]52;c;ZXZpbA==\a\r\nc\u009b1;1Hd\te"
+	tests := []struct {
+		policy s.SanitizePolicy
+		a      string
+	}{
+		{s.SanitizeNone, in},
+		{s.SanitizeStrip, "ab\nc1;1Hd\te"},
+		{s.SanitizeEscape, `a\x1b[31mb\x1b[0m\x1b[2J\x1b]52;c;ZXZpbA==\a\r` + "\nc" + `\u009b1;1Hd` + "\te"},
+		{s.SanitizeSGROnly, "a\033[31mb\033[0m\nc1;1Hd\te"},
+	}
+	for _, tt := range tests {
+		if r := s.Sanitize(in, tt.policy); r != tt.a {
+			t.Errorf("Get %#v, want %#v", r, tt.a)
+		}
+	}
+
+	// raw 8-bit CSI
+	if r := s.Sanitize("a\x9b2Jb\xff", s.SanitizeStrip); r != "a2Jb\xff" {
+		t.Errorf("Get %#v, want %#v", r, "a2Jb\xff")
+	}
+	if r := s.Sanitize("a\x9b2Jb", s.SanitizeEscape); r != `a\x9b2Jb` {
+		t.Errorf("Get %#v, want %#v", r, `a\x9b2Jb`)
+	}
+
+	v := s.NewANSIStrings()
+	v.SetSanitizePolicy(s.SanitizeStrip).Str("\033[2Jtest").Red()
+	a := "\033[31mtest\033[0m"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+}
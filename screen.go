@@ -0,0 +1,193 @@
+package ansistrings
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Screen is double-buffered screen. Flush outputs only difference from previous frame.
+type Screen struct {
+	w       io.Writer
+	width   int
+	height  int
+	front   [][]Cell
+	back    [][]Cell
+	profile *Profile
+}
+
+// NewScreen returns new Screen which has given size
+func NewScreen(w io.Writer, width int, height int) *Screen {
+	if width < 1 || height < 1 {
+		panic("width and height should be greater than 0")
+	}
+	s := &Screen{w: w}
+	s.Resize(width, height)
+	return s
+}
+
+// Resize changes size of Screen. whole screen is redrawn by next Flush.
+func (s *Screen) Resize(width int, height int) *Screen {
+	s.width = width
+	s.height = height
+	s.front = make([][]Cell, height)
+	back := make([][]Cell, height)
+	for y := range back {
+		// Rune 0 means unknown cell
+		s.front[y] = make([]Cell, width)
+		back[y] = make([]Cell, width)
+		for x := range back[y] {
+			back[y][x].Rune = ' '
+			if y < len(s.back) && x < len(s.back[y]) {
+				back[y][x] = s.back[y][x]
+			}
+		}
+	}
+	s.back = back
+	return s
+}
+
+// SetProfile sets Profile to render Screen
+func (s *Screen) SetProfile(p Profile) *Screen {
+	s.profile = &p
+	return s
+}
+
+func (s *Screen) currentProfile() Profile {
+	if s.profile != nil {
+		return *s.profile
+	}
+	return DefaultProfile
+}
+
+// Size returns width and height of Screen
+func (s *Screen) Size() (width int, height int) {
+	return s.width, s.height
+}
+
+// Clear clears screen to draw next frame
+func (s *Screen) Clear() *Screen {
+	for y := range s.back {
+		for x := range s.back[y] {
+			s.back[y][x] = Cell{Rune: ' '}
+		}
+	}
+	return s
+}
+
+// Set sets Cell at given position. top left is (1, 1) as same as Pos.
+// wide character uses next cell, too. It is replaced by space at right edge.
+func (s *Screen) Set(x int, y int, c Cell) *Screen {
+	if x < 1 || x > s.width || y < 1 || y > s.height {
+		return s
+	}
+	row := s.back[y-1]
+	x--
+	w := 1
+	if runeWidth(c.Rune) == 2 {
+		w = 2
+		if x+1 >= s.width {
+			c.Rune = ' '
+			w = 1
+		}
+	}
+	// overwriting a half of wide character clears the other half
+	if row[x].Rune == 0 && x > 0 {
+		row[x-1] = Cell{Rune: ' '}
+	}
+	if x+w < s.width && row[x+w].Rune == 0 {
+		row[x+w] = Cell{Rune: ' '}
+	}
+	row[x] = c
+	if w == 2 {
+		row[x+1] = Cell{Style: c.Style}
+	}
+	return s
+}
+
+// Cell returns Cell of next frame at given position. top left is (1, 1) as same as Pos.
+func (s *Screen) Cell(x int, y int) Cell {
+	if x >= 1 && x <= s.width && y >= 1 && y <= s.height {
+		return s.back[y-1][x-1]
+	}
+	return Cell{Rune: ' '}
+}
+
+// Draw draws ANSIStrings at given position. each line starts at column x and is clipped at edge of Screen.
+// escape sequences other than SGR are ignored.
+func (s *Screen) Draw(x int, y int, v *ANSIStrings) *Screen {
+	for i, line := range toLines(v) {
+		col := x
+		for _, c := range line {
+			w := runeWidth(c.Rune)
+			if w == 0 {
+				continue
+			}
+			s.Set(col, y+i, c)
+			col += w
+		}
+	}
+	return s
+}
+
+// Flush outputs minimal cursor moves and SGR changes to make terminal same as drawn frame
+func (s *Screen) Flush() error {
+	b := strings.Builder{}
+	cx, cy := -1, -1
+	pen := ANSIStyle{}
+	for y := range s.back {
+		for x, c := range s.back[y] {
+			if c == s.front[y][x] {
+				continue
+			}
+			if c.Rune == 0 {
+				// right half of wide character is written with left half
+				s.front[y][x] = c
+				continue
+			}
+			if cx != x || cy != y {
+				b.WriteString(moveSequence(cx, cy, x, y))
+			}
+			if c.Style != pen {
+				b.WriteString(_reset + c.Style.sequence())
+				pen = c.Style
+			}
+			b.WriteRune(c.Rune)
+			s.front[y][x] = c
+			cx, cy = x+1, y
+			if runeWidth(c.Rune) == 2 {
+				cx++
+			}
+			if cx >= s.width {
+				// cursor position at right edge depends on terminal
+				cx, cy = -1, -1
+			}
+		}
+	}
+	if b.Len() == 0 {
+		return nil
+	}
+	if pen != (ANSIStyle{}) {
+		b.WriteString(_reset)
+	}
+	out := b.String()
+	if s.currentProfile().SyncOutput {
+		out = _beginSync + out + _endSync
+	}
+	_, err := io.WriteString(s.w, out)
+	return err
+}
+
+// moveSequence returns shortest sequence to move cursor. cursor position is unknown if fromX is -1.
+func moveSequence(fromX int, fromY int, x int, y int) string {
+	if fromX >= 0 && fromY == y {
+		if x > fromX {
+			return fmt.Sprintf("\033[%dC", x-fromX)
+		}
+		return fmt.Sprintf("\033[%dD", fromX-x)
+	}
+	if fromX >= 0 && x == 0 && y == fromY+1 {
+		return "\r\n"
+	}
+	return fmt.Sprintf("\033[%d;%dH", y+1, x+1)
+}
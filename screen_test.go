@@ -0,0 +1,80 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestScreen(t *testing.T) {
+	b := &bytes.Buffer{}
+	vt := s.NewVT(10, 3)
+	scr := s.NewScreen(b, 10, 3).SetProfile(s.Profile{})
+
+	v1, v2 := s.NewANSIStrings(), s.NewANSIStrings()
+	scr.Draw(2, 1, v1.Str("cpu").Bold().Str(" 10%"))
+	scr.Draw(2, 2, v2.Str("mem 2G"))
+	if err := scr.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	vt.Write(b.Bytes())
+	a := " cpu 10%\n mem 2G"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+
+	b.Reset()
+	v3 := s.NewANSIStrings()
+	scr.Draw(2, 1, v3.Str("cpu").Bold().Str(" 20%"))
+	scr.Flush()
+	if b.String() != "\033[1;6H2" {
+		t.Errorf("Get %#v, want only changed cell", b.String())
+	}
+	vt.Write(b.Bytes())
+	if vt.Line(1) != " cpu 20%" {
+		t.Errorf("Get %#v, want %#v", vt.Line(1), " cpu 20%")
+	}
+
+	b.Reset()
+	scr.Flush()
+	if b.Len() != 0 {
+		t.Errorf("Get %#v, want no output", b.String())
+	}
+
+	// lines are clipped at edge
+	v4, v5 := s.NewANSIStrings(), s.NewANSIStrings()
+	scr.Clear().Draw(1, 3, v4.Str("0123456789AB\nnext")).Draw(-1, 1, v5.Str("xyz"))
+	b.Reset()
+	scr.Flush()
+	vt.Write(b.Bytes())
+	a = "z\n\n0123456789"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+
+	// wide characters use 2 cells
+	v6, v7 := s.NewANSIStrings(), s.NewANSIStrings()
+	scr.Clear().Draw(1, 1, v6.Str("日本ab"))
+	scr.Flush()
+	b.Reset()
+	scr.Draw(1, 1, v7.Str("日本ac"))
+	scr.Flush()
+	if b.String() != "\033[1;6Hc" {
+		t.Errorf("Get %#v, want only changed cell", b.String())
+	}
+	vt = s.NewVT(10, 3)
+	b.Reset()
+	scr.Resize(10, 3)
+	scr.Flush()
+	vt.Write(b.Bytes())
+	a = "日本ac"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+
+	scr.Set(2, 1, s.Cell{Rune: 'x'})
+	if c := scr.Cell(1, 1); c.Rune != ' ' {
+		t.Errorf("Get %#v, want left half of wide character cleared", c)
+	}
+}
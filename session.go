@@ -0,0 +1,81 @@
+package ansistrings
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// Session is full-screen session on alternate screen.
+// Terminal is restored on Close, panic in RunSession, SIGINT and SIGTERM.
+type Session struct {
+	w       io.Writer
+	mu      sync.Mutex
+	closed  bool
+	signals chan os.Signal
+	done    chan struct{}
+}
+
+// NewSession enters alternate screen, hides cursor and returns new Session
+func NewSession(w io.Writer) *Session {
+	s := &Session{w: w, signals: make(chan os.Signal, 1), done: make(chan struct{})}
+	io.WriteString(w, _enterAltScreen+_hideCursor+"\033[2J\033[1;1H")
+	signal.Notify(s.signals, os.Interrupt, syscall.SIGTERM)
+	go s.wait()
+	return s
+}
+
+// RunSession runs f in new Session and restores terminal after f returns or panics
+func RunSession(w io.Writer, f func(s *Session)) {
+	s := NewSession(w)
+	defer s.Close()
+	f(s)
+}
+
+func (s *Session) wait() {
+	select {
+	case sig := <-s.signals:
+		s.Close()
+		// terminate as the signal was not handled
+		if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+			return
+		}
+		os.Exit(1)
+	case <-s.done:
+	}
+}
+
+// ErrSessionClosed is returned when Session is closed
+var ErrSessionClosed = errors.New("session is closed")
+
+// Write writes to terminal of Session. It returns ErrSessionClosed after Close.
+func (s *Session) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, ErrSessionClosed
+	}
+	return s.w.Write(p)
+}
+
+// Print prints ANSIStrings and discard them. ANSIStrings are discarded without output after Close.
+func (s *Session) Print(v *ANSIStrings) *Session {
+	v.Fprint(s)
+	return s
+}
+
+// Close shows cursor and leaves alternate screen. It is safe to call Close multiple times.
+func (s *Session) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	signal.Stop(s.signals)
+	close(s.done)
+	io.WriteString(s.w, _reset+_showCursor+_leaveAltScreen)
+}
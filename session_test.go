@@ -0,0 +1,50 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestSession(t *testing.T) {
+	b := &bytes.Buffer{}
+	vt := s.NewVT(20, 5)
+	vt.WriteString("main screen")
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("panic should be propagated")
+			}
+		}()
+		s.RunSession(b, func(ss *s.Session) {
+			v := s.NewANSIStrings()
+			ss.Print(v.Str("full screen"))
+			vt.Write(b.Bytes())
+			if !vt.AltScreen() || !vt.CursorHidden() || vt.Text() != "full screen" {
+				t.Errorf("Get %#v, want full screen on alternate screen", vt.Text())
+			}
+			b.Reset()
+			panic("test")
+		})
+	}()
+
+	vt.Write(b.Bytes())
+	if vt.AltScreen() || vt.CursorHidden() || vt.Text() != "main screen" {
+		t.Errorf("Get %#v, want main screen", vt.Text())
+	}
+
+	b.Reset()
+	ss := s.NewSession(b)
+	ss.Close()
+	b.Reset()
+	v := s.NewANSIStrings()
+	ss.Print(v.Str("closed"))
+	if _, err := ss.Write([]byte("closed")); err != s.ErrSessionClosed {
+		t.Errorf("Get %#v, want ErrSessionClosed", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("Get %#v, want no output after Close", b.String())
+	}
+}
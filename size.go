@@ -0,0 +1,43 @@
+package ansistrings
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// Size is width and height of terminal
+type Size struct {
+	Width  int
+	Height int
+}
+
+// ErrUnknownSize is returned when size of terminal cannot be detected
+var ErrUnknownSize = errors.New("unknown terminal size")
+
+// TerminalSize returns size of terminal of f.
+// COLUMNS and LINES are used if size cannot be get from f.
+func TerminalSize(f *os.File) (Size, error) {
+	if f != nil {
+		if s, err := getWinsize(f); err == nil && s.Width > 0 && s.Height > 0 {
+			return s, nil
+		}
+	}
+	w, err1 := strconv.Atoi(os.Getenv("COLUMNS"))
+	h, err2 := strconv.Atoi(os.Getenv("LINES"))
+	if err1 != nil || err2 != nil || w < 1 || h < 1 {
+		return Size{}, ErrUnknownSize
+	}
+	return Size{Width: w, Height: h}, nil
+}
+
+// IsTerminal returns whether w is terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
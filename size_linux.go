@@ -0,0 +1,21 @@
+package ansistrings
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+func getWinsize(f *os.File) (Size, error) {
+	ws := struct {
+		row    uint16
+		col    uint16
+		xpixel uint16
+		ypixel uint16
+	}{}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return Size{}, errno
+	}
+	return Size{Width: int(ws.col), Height: int(ws.row)}, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package ansistrings
+
+import "os"
+
+func getWinsize(f *os.File) (Size, error) {
+	return Size{}, ErrUnknownSize
+}
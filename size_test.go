@@ -0,0 +1,29 @@
+package ansistrings_test
+
+import (
+	"os"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestTerminalSize(t *testing.T) {
+	f, err := os.CreateTemp("", "size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	t.Setenv("COLUMNS", "100")
+	t.Setenv("LINES", "40")
+	size, err := s.TerminalSize(f)
+	if err != nil || size != (s.Size{Width: 100, Height: 40}) {
+		t.Errorf("Get %#v, %v, want 100x40", size, err)
+	}
+
+	t.Setenv("COLUMNS", "")
+	if _, err := s.TerminalSize(f); err != s.ErrUnknownSize {
+		t.Errorf("Get %v, want %v", err, s.ErrUnknownSize)
+	}
+}
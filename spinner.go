@@ -0,0 +1,160 @@
+package ansistrings
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// frame sets of Spinner
+var (
+	SpinnerDots    = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	SpinnerLine    = []string{"-", "\\", "|", "/"}
+	SpinnerBraille = []string{"⣾", "⣽", "⣻", "⢿", "⡿", "⣟", "⣯", "⣷"}
+	SpinnerArc     = []string{"◜", "◠", "◝", "◞", "◡", "◟"}
+)
+
+// Spinner draws animated frame and message in a line
+type Spinner struct {
+	// Frames are drawn in turn. any strings can be used as custom frames.
+	Frames []string
+	// Interval is interval of frames
+	Interval time.Duration
+	// Style is style of frame
+	Style ANSIStyle
+
+	w       io.Writer
+	message string
+	frame   int
+	stop    chan struct{}
+	done    chan struct{}
+	mu      sync.Mutex
+}
+
+// NewSpinner returns new Spinner with message
+func NewSpinner(w io.Writer, message string) *Spinner {
+	style := NewANSIStyle()
+	style.Cyan()
+	return &Spinner{
+		Frames:   SpinnerDots,
+		Interval: 80 * time.Millisecond,
+		Style:    style,
+		w:        w,
+		message:  message,
+	}
+}
+
+// Start starts animation
+func (s *Spinner) Start() *Spinner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return s
+	}
+	if len(s.Frames) == 0 {
+		panic("Frames should not be empty")
+	}
+	if s.Interval <= 0 {
+		panic("Interval should be greater than 0")
+	}
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	v := NewANSIStrings()
+	v.HideCursor()
+	s.draw(&v)
+	go s.run(s.stop, s.done)
+	return s
+}
+
+func (s *Spinner) run(stop chan struct{}, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame = (s.frame + 1) % len(s.Frames)
+			v := NewANSIStrings()
+			s.draw(&v)
+			s.mu.Unlock()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// SetMessage changes message
+func (s *Spinner) SetMessage(message string) *Spinner {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = message
+	if s.stop != nil {
+		v := NewANSIStrings()
+		s.draw(&v)
+	}
+	return s
+}
+
+// Render returns ANSIStrings of current frame and message
+func (s *Spinner) Render() *ANSIStrings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v := NewANSIStrings()
+	s.render(&v)
+	return &v
+}
+
+func (s *Spinner) render(v *ANSIStrings) {
+	v.Str(s.Frames[s.frame%len(s.Frames)]).Style(s.Style)
+	if s.message != "" {
+		v.Str(" " + s.message)
+	}
+}
+
+// draw redraws line of Spinner after v
+func (s *Spinner) draw(v *ANSIStrings) {
+	v.Column(1)
+	s.render(v)
+	v.EraseLineToEnd().Fprint(s.w)
+}
+
+// Stop stops animation and erases line of Spinner
+func (s *Spinner) Stop() {
+	if s.halt() {
+		v := NewANSIStrings()
+		v.Column(1).EraseLine().ShowCursor().Fprint(s.w)
+	}
+}
+
+// Success stops animation and leaves green check mark and message
+func (s *Spinner) Success(message string) {
+	s.finish("✔", Green, message)
+}
+
+// Failure stops animation and leaves red cross mark and message
+func (s *Spinner) Failure(message string) {
+	s.finish("✖", Red, message)
+}
+
+func (s *Spinner) finish(mark string, color int, message string) {
+	if !s.halt() {
+		return
+	}
+	v := NewANSIStrings()
+	v.Column(1).Str(mark).Color(color).Str(" " + message).EraseLineToEnd().Str("\n").ShowCursor().Fprint(s.w)
+}
+
+// halt stops goroutine of animation. it returns false if Spinner is not running.
+func (s *Spinner) halt() bool {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop = nil
+	s.mu.Unlock()
+	if stop == nil {
+		return false
+	}
+	close(stop)
+	<-done
+	return true
+}
@@ -0,0 +1,55 @@
+package ansistrings_test
+
+import (
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestSpinner(t *testing.T) {
+	vt := s.NewVT(20, 0)
+	vt.WriteString("before\n")
+	sp := s.NewSpinner(vt, "loading")
+	sp.Frames = []string{"[=  ]", "[ = ]", "[  =]"}
+	sp.Style = s.NewANSIStyle()
+	if str := sp.Render().String(); str != "[=  ] loading" {
+		t.Errorf("Get %#v, want %#v", str, "[=  ] loading")
+	}
+
+	sp.Interval = time.Millisecond
+	sp.Start()
+	if !vt.CursorHidden() {
+		t.Error("cursor should be hidden")
+	}
+	time.Sleep(10 * time.Millisecond)
+	sp.SetMessage("saving")
+	sp.Success("saved")
+	a := "before\n✔ saved"
+	if vt.Text() != a || vt.CursorHidden() {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	green := s.NewANSIStyle()
+	green.Green()
+	if c := vt.Cell(1, 2); c.Style != green {
+		t.Errorf("Get %#v, want green", c)
+	}
+
+	sp = s.NewSpinner(vt, "loading").Start()
+	sp.Stop()
+	sp.Stop()
+	if vt.Text() != a || vt.CursorHidden() {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+}
+
+func TestSpinnerInvalidInterval(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Start should panic")
+		}
+	}()
+	sp := s.NewSpinner(s.NewVT(20, 0), "loading")
+	sp.Interval = 0
+	sp.Start()
+}
@@ -0,0 +1,77 @@
+package ansistrings
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// StatusLine reserves last rows of terminal for status.
+// Log written to StatusLine scrolls in the rest of rows.
+type StatusLine struct {
+	w      io.Writer
+	height int
+	rows   int
+	status []string
+	mu     sync.Mutex
+}
+
+// NewStatusLine reserves last rows of terminal which has given height and returns new StatusLine
+func NewStatusLine(w io.Writer, height int, rows int) *StatusLine {
+	if rows < 1 || height <= rows+1 {
+		panic("rows should be greater than 0 and less than height - 1")
+	}
+	l := &StatusLine{w: w, height: height, rows: rows, status: make([]string, rows)}
+	v := NewANSIStrings()
+	// push existing output up to make space of status
+	v.Str(strings.Repeat("\n", rows)).Up(rows).
+		SaveCursor().
+		ScrollRegion(1, height-rows).
+		RestoreCursor()
+	v.Fprint(w)
+	return l
+}
+
+// Write writes log to scroll region
+func (l *StatusLine) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// Update redraws status with given ANSIStrings. each line of it is drawn in each reserved row.
+func (l *StatusLine) Update(status *ANSIStrings) *StatusLine {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lines := strings.Split(status.String(), "\n")
+	for i := range l.status {
+		l.status[i] = ""
+		if i < len(lines) {
+			l.status[i] = lines[i]
+		}
+	}
+	l.draw()
+	return l
+}
+
+func (l *StatusLine) draw() {
+	v := NewANSIStrings()
+	v.SaveCursor()
+	for i, line := range l.status {
+		v.Pos(1, l.height-l.rows+1+i).EraseLine().Str(line)
+	}
+	v.RestoreCursor().Fprint(l.w)
+}
+
+// Close resets scroll region and erases status
+func (l *StatusLine) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v := NewANSIStrings()
+	v.SaveCursor().
+		ResetScrollRegion().
+		Pos(1, l.height-l.rows+1).
+		EraseBelow().
+		RestoreCursor().
+		Fprint(l.w)
+}
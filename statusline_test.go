@@ -0,0 +1,34 @@
+package ansistrings_test
+
+import (
+	"fmt"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestStatusLine(t *testing.T) {
+	vt := s.NewVT(20, 5)
+	l := s.NewStatusLine(vt, 5, 1)
+	for i := 1; i <= 6; i++ {
+		v := s.NewANSIStrings()
+		fmt.Fprintf(l, "log %d\n", i)
+		l.Update(v.Str(fmt.Sprintf("status %d/6", i)).Bold())
+	}
+	a := "log 4\nlog 5\nlog 6\n\nstatus 6/6"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	bold := s.NewANSIStyle()
+	bold.Bold()
+	if c := vt.Cell(1, 5); c.Style != bold {
+		t.Errorf("Get %#v, want bold", c)
+	}
+
+	l.Close()
+	fmt.Fprint(vt, "log 7\nlog 8\n")
+	a = "log 5\nlog 6\nlog 7\nlog 8"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+}
@@ -0,0 +1,134 @@
+package ansistrings
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+)
+
+// SVGOptions is settings to render ANSIStrings as SVG
+type SVGOptions struct {
+	// Columns is width of screen(default 80)
+	Columns int
+	// Rows is height of screen. 0 means fitting to output.
+	Rows       int
+	FontFamily string
+	FontSize   float64
+	Theme      Theme
+	// Window draws window frame with Title around screen
+	Window bool
+	Title  string
+}
+
+// NewSVGOptions returns new SVGOptions with default settings
+func NewSVGOptions() SVGOptions {
+	return SVGOptions{
+		Columns:    80,
+		FontFamily: "Menlo, Monaco, Consolas, 'DejaVu Sans Mono', monospace",
+		FontSize:   14,
+		Theme:      DefaultTheme,
+		Window:     true,
+	}
+}
+
+const (
+	_svgPadding     = 10
+	_svgWindowTitle = 30
+)
+
+// SVG returns SVG image of ANSIStrings like terminal screenshot
+func (s *ANSIStrings) SVG(opt SVGOptions) string {
+	if opt.Columns < 1 {
+		opt.Columns = 80
+	}
+	if opt.FontSize <= 0 {
+		opt.FontSize = 14
+	}
+	if opt.FontFamily == "" {
+		opt.FontFamily = "monospace"
+	}
+	v := NewVT(opt.Columns, opt.Rows)
+	v.WriteString(s.String())
+	rows := v.rows()
+
+	cw := opt.FontSize * 0.6
+	lh := opt.FontSize * 1.2
+	top := float64(_svgPadding)
+	if opt.Window {
+		top += _svgWindowTitle
+	}
+	width := round2(float64(opt.Columns)*cw + _svgPadding*2)
+	height := round2(float64(len(rows))*lh + top + _svgPadding)
+	t := opt.Theme
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="0 0 %g %g">`+"\n", width, height, width, height)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" rx="6" fill="%s"/>`+"\n", hexColor(t.Background))
+	if opt.Window {
+		for i, c := range []string{"#ff5f56", "#ffbd2e", "#27c93f"} {
+			fmt.Fprintf(b, `<circle cx="%d" cy="15" r="6" fill="%s"/>`+"\n", 20+i*20, c)
+		}
+		if opt.Title != "" {
+			fmt.Fprintf(b, `<text x="%g" y="20" fill="%s" font-family="%s" font-size="%g" text-anchor="middle">%s</text>`+"\n",
+				width/2, hexColor(t.Foreground), html.EscapeString(opt.FontFamily), opt.FontSize, html.EscapeString(opt.Title))
+		}
+	}
+	fmt.Fprintf(b, `<g font-family="%s" font-size="%g" xml:space="preserve">`+"\n", html.EscapeString(opt.FontFamily), opt.FontSize)
+	for y, row := range rows {
+		for x := 0; x < len(row); {
+			end := x + 1
+			for end < len(row) && row[end].Style == row[x].Style {
+				end++
+			}
+			writeSVGRun(b, t, row[x:end], _svgPadding+float64(x)*cw, top+float64(y)*lh, cw, lh)
+			x = end
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+	return b.String()
+}
+
+func writeSVGRun(b *strings.Builder, t Theme, run []Cell, x float64, y float64, cw float64, lh float64) {
+	style := run[0].Style
+	fg, bg := t.colors(style)
+	w := float64(len(run)) * cw
+	if bg != t.Background {
+		fmt.Fprintf(b, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`+"\n", round2(x), round2(y), round2(w), round2(lh), hexColor(bg))
+	}
+	text := make([]rune, 0, len(run))
+	for _, c := range run {
+		if c.Rune != 0 {
+			text = append(text, c.Rune)
+		}
+	}
+	if style.withConceal || strings.TrimSpace(string(text)) == "" {
+		return
+	}
+	attrs := fmt.Sprintf(` fill="%s"`, hexColor(fg))
+	if style.withBold {
+		attrs += ` font-weight="bold"`
+	}
+	if style.withFaint {
+		attrs += ` opacity="0.5"`
+	}
+	if style.withItalic {
+		attrs += ` font-style="italic"`
+	}
+	decorations := []string{}
+	if style.withUnderLine {
+		decorations = append(decorations, "underline")
+	}
+	if style.withDelete {
+		decorations = append(decorations, "line-through")
+	}
+	if len(decorations) > 0 {
+		attrs += fmt.Sprintf(` text-decoration="%s"`, strings.Join(decorations, " "))
+	}
+	fmt.Fprintf(b, `<text x="%g" y="%g" textLength="%g" lengthAdjust="spacingAndGlyphs"%s>%s</text>`+"\n",
+		round2(x), round2(y+lh*0.8), round2(w), attrs, html.EscapeString(string(text)))
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
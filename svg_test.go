@@ -0,0 +1,36 @@
+package ansistrings_test
+
+import (
+	"strings"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestSVG(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Clear().Str("hello").Red().Pos(3, 2).Str("<b>").BgColor(s.Blue)
+	opt := s.NewSVGOptions()
+	opt.Columns = 10
+	opt.Title = "demo"
+	svg := v.SVG(opt)
+
+	for _, a := range []string{
+		`<text x="10" y="53.44" textLength="42" lengthAdjust="spacingAndGlyphs" fill="#cd0000">hello</text>`,
+		`<rect x="26.8" y="56.8" width="25.2" height="16.8" fill="#0000ee"/>`,
+		`&lt;b&gt;`,
+		`>demo</text>`,
+	} {
+		if !strings.Contains(svg, a) {
+			t.Errorf("Get %s, want to contain %s", svg, a)
+		}
+	}
+
+	opt.Window = false
+	opt.Rows = 3
+	svg = v.SVG(opt)
+	a := `height="70.4"`
+	if !strings.Contains(svg, a) {
+		t.Errorf("Get %s, want to contain %s", svg, a)
+	}
+}
@@ -0,0 +1,33 @@
+package ansistrings_test
+
+import (
+	"bytes"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestSync(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.BeginSync().Clear().Str("frame").EndSync()
+	a := "\033[?2026h\033[2J\033[1;1Hframe\033[?2026l"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	b := &bytes.Buffer{}
+	v = s.NewANSIStrings()
+	v.SetProfile(s.Profile{SyncOutput: true})
+	v.Str("frame1").Pause(1).Str("frame2").Red().Fprint(b)
+	a = "\033[?2026hframe1\033[?2026l\033[?2026h\033[31mframe2\033[0m\033[?2026l"
+	if b.String() != a {
+		t.Errorf("Get %#v, want %#v", b.String(), a)
+	}
+
+	b.Reset()
+	v.SetProfile(s.Profile{})
+	v.Str("frame").Fprint(b)
+	if b.String() != "frame" {
+		t.Errorf("Get %#v, want %#v", b.String(), "frame")
+	}
+}
@@ -0,0 +1,272 @@
+package ansistrings
+
+import "strings"
+
+// Column is setting of column of Table
+type Column struct {
+	Align Align
+	// Width is fixed width of column. It is computed from cells if it is 0.
+	Width int
+	// MaxWidth is maximum width of column. It is not limited if it is 0.
+	MaxWidth int
+	// Wrap wraps cell which is wider than column. Cell is truncated with "…" if it is false.
+	Wrap bool
+}
+
+// Table renders rows of cells with border.
+// Cells can be ANSIStrings, ANSIString, string or any value and width of them is computed from visible text.
+type Table struct {
+	Border      Border
+	BorderStyle ANSIStyle
+	// HeaderStyle is style of header cells which have no style
+	HeaderStyle ANSIStyle
+	// Zebra is style to set background of every other row. e.g. t.Zebra.BgColorN(236)
+	Zebra ANSIStyle
+	// Padding is number of spaces of both sides of cell
+	Padding int
+	Columns []Column
+
+	header [][]styledLine
+	rows   [][][]styledLine
+}
+
+// NewTable returns new Table
+func NewTable() *Table {
+	t := &Table{Border: BorderSingle, Padding: 1}
+	t.HeaderStyle.Bold()
+	return t
+}
+
+// Header sets header cells
+func (t *Table) Header(cells ...interface{}) *Table {
+	t.header = t.cells(cells)
+	return t
+}
+
+// Row adds row of cells
+func (t *Table) Row(cells ...interface{}) *Table {
+	t.rows = append(t.rows, t.cells(cells))
+	return t
+}
+
+func (t *Table) cells(cells []interface{}) [][]styledLine {
+	row := make([][]styledLine, len(cells))
+	for i, c := range cells {
+		row[i] = toLines(c)
+	}
+	return row
+}
+
+func (t *Table) column(i int) Column {
+	if i < len(t.Columns) {
+		return t.Columns[i]
+	}
+	return Column{}
+}
+
+// widths returns width of each column
+func (t *Table) widths() []int {
+	n := len(t.header)
+	for _, row := range t.rows {
+		if len(row) > n {
+			n = len(row)
+		}
+	}
+	min := 1
+	if t.Border.markdown {
+		// "---" is required for markdown
+		min = 3
+	}
+	widths := make([]int, n)
+	for i := range widths {
+		c := t.column(i)
+		if c.Width > 0 {
+			widths[i] = c.Width
+			continue
+		}
+		for _, row := range append([][][]styledLine{t.header}, t.rows...) {
+			if i >= len(row) {
+				continue
+			}
+			for _, l := range row[i] {
+				if l.width() > widths[i] {
+					widths[i] = l.width()
+				}
+			}
+		}
+		if c.MaxWidth > 0 && widths[i] > c.MaxWidth {
+			widths[i] = c.MaxWidth
+		}
+		if widths[i] < min {
+			widths[i] = min
+		}
+	}
+	return widths
+}
+
+// Render returns ANSIStrings of Table.
+// BorderMarkdown is rendered as plain text with escaped "|" and links like "[text](url)".
+// Cell of markdown is always one row. Lines of cell are joined with "<br>" and wrapped cell is not split.
+func (t *Table) Render() *ANSIStrings {
+	if !t.Border.markdown {
+		return t.render()
+	}
+	widths := t.widths()
+	md := *t
+	md.HeaderStyle, md.Zebra, md.BorderStyle = ANSIStyle{}, ANSIStyle{}, ANSIStyle{}
+	// cells are already truncated and width of column is computed from markdown text
+	md.Columns = make([]Column, len(t.Columns))
+	for i, c := range t.Columns {
+		md.Columns[i].Align = c.Align
+	}
+	md.header = t.markdownCells(widths, t.header)
+	md.rows = make([][][]styledLine, len(t.rows))
+	for i, row := range t.rows {
+		md.rows[i] = t.markdownCells(widths, row)
+	}
+	return md.render()
+}
+
+// markdownCells returns cells as plain text of markdown. text is truncated before adding markdown syntax.
+func (t *Table) markdownCells(widths []int, cells [][]styledLine) [][]styledLine {
+	md := make([][]styledLine, len(cells))
+	for i, lines := range cells {
+		m := styledLine{}
+		for j, l := range lines {
+			if j > 0 {
+				m = m.append(text("<br>", ANSIStyle{}))
+			}
+			if !t.column(i).Wrap {
+				l = l.truncate(widths[i], "…")
+			}
+			m = m.append(markdownLine(l))
+		}
+		md[i] = []styledLine{m}
+	}
+	return md
+}
+
+// markdownLine returns line as plain text with escaped "|" and links of markdown
+func markdownLine(l styledLine) styledLine {
+	m := styledLine{}
+	url := ""
+	for _, c := range l {
+		if c.Style.link.url != url {
+			if url != "" {
+				m = m.append(text("]("+url+")", ANSIStyle{}))
+			}
+			url = c.Style.link.url
+			if url != "" {
+				m = m.append(text("[", ANSIStyle{}))
+			}
+		}
+		if c.Rune == '|' {
+			m = m.append(text("\\", ANSIStyle{}))
+		}
+		m = append(m, Cell{Rune: c.Rune})
+	}
+	if url != "" {
+		m = m.append(text("]("+url+")", ANSIStyle{}))
+	}
+	return m
+}
+
+func (t *Table) render() *ANSIStrings {
+	widths := t.widths()
+	lines := []styledLine{}
+	if !t.Border.markdown {
+		lines = append(lines, t.rule(widths, t.Border.TopLeft, t.Border.TopT, t.Border.TopRight))
+	}
+	if len(t.header) > 0 {
+		lines = append(lines, t.row(widths, t.header, true, ANSIStyle{})...)
+		if t.Border.markdown {
+			lines = append(lines, t.markdownRule(widths))
+		} else {
+			lines = append(lines, t.rule(widths, t.Border.LeftT, t.Border.Cross, t.Border.RightT))
+		}
+	}
+	for i, row := range t.rows {
+		bg := ANSIStyle{}
+		if i%2 == 1 {
+			bg = t.Zebra
+		}
+		lines = append(lines, t.row(widths, row, false, bg)...)
+	}
+	if !t.Border.markdown {
+		lines = append(lines, t.rule(widths, t.Border.BottomLeft, t.Border.BottomT, t.Border.BottomRight))
+	}
+	return joinLines(lines)
+}
+
+// String returns rendered Table
+func (t *Table) String() string {
+	return t.Render().String()
+}
+
+// rule returns horizontal line of border
+func (t *Table) rule(widths []int, left string, junction string, right string) styledLine {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat(t.Border.Horizontal, w+t.Padding*2)
+	}
+	return text(left+strings.Join(parts, junction)+right, t.BorderStyle)
+}
+
+// markdownRule returns separator of header and rows with alignment of markdown
+func (t *Table) markdownRule(widths []int) styledLine {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		n := w + t.Padding*2
+		switch t.column(i).Align {
+		case AlignCenter:
+			parts[i] = ":" + strings.Repeat("-", n-2) + ":"
+		case AlignRight:
+			parts[i] = strings.Repeat("-", n-1) + ":"
+		default:
+			parts[i] = strings.Repeat("-", n)
+		}
+	}
+	return text("|"+strings.Join(parts, "|")+"|", t.BorderStyle)
+}
+
+// row returns lines of row. cells are wrapped or truncated and padded to width of column.
+func (t *Table) row(widths []int, cells [][]styledLine, header bool, bg ANSIStyle) []styledLine {
+	columns := make([][]styledLine, len(widths))
+	height := 1
+	for i, w := range widths {
+		c := t.column(i)
+		if i < len(cells) {
+			for _, l := range cells[i] {
+				if header {
+					l = l.defaultStyle(t.HeaderStyle)
+				}
+				if l.width() <= w {
+					columns[i] = append(columns[i], l)
+				} else if c.Wrap {
+					columns[i] = append(columns[i], l.wrap(w)...)
+				} else {
+					columns[i] = append(columns[i], l.truncate(w, "…"))
+				}
+			}
+		}
+		if len(columns[i]) > height {
+			height = len(columns[i])
+		}
+	}
+	lines := make([]styledLine, height)
+	for y := range lines {
+		l := text(t.Border.Vertical, t.BorderStyle)
+		for i, w := range widths {
+			cell := styledLine{}
+			if y < len(columns[i]) {
+				cell = columns[i][y]
+			}
+			cell = spaces(t.Padding, ANSIStyle{}).
+				append(cell.pad(w, t.column(i).Align, ANSIStyle{})).
+				append(spaces(t.Padding, ANSIStyle{}))
+			l = l.append(cell.background(bg)).append(text(t.Border.Vertical, t.BorderStyle))
+		}
+		lines[y] = l
+	}
+	return lines
+}
@@ -0,0 +1,116 @@
+package ansistrings_test
+
+import (
+	"regexp"
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestTable(t *testing.T) {
+	name := s.NewANSIStrings()
+	name.Str("go").Red().Bold().Str("lang")
+	tb := s.NewTable()
+	tb.HeaderStyle = s.NewANSIStyle()
+	tb.Columns = []s.Column{{}, {Align: s.AlignRight}, {MaxWidth: 8, Wrap: true}}
+	tb.Header("name", "stars", "note").
+		Row(&name, 120, "fast and simple").
+		Row("\033[34mrust\033[0m", 95, "safe")
+	a := "┌────────┬───────┬──────────┐\n" +
+		"│ name   │ stars │ note     │\n" +
+		"├────────┼───────┼──────────┤\n" +
+		"│ golang │   120 │ fast and │\n" +
+		"│        │       │ simple   │\n" +
+		"│ rust   │    95 │ safe     │\n" +
+		"└────────┴───────┴──────────┘"
+	vt := s.NewVT(40, 0)
+	vt.WriteString(tb.String())
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	red := s.NewANSIStyle()
+	red.Red().Bold()
+	blue := s.NewANSIStyle()
+	blue.Blue()
+	if c := vt.Cell(3, 4); c.Style != red {
+		t.Errorf("Get %#v, want red", c)
+	}
+	if c := vt.Cell(3, 6); c.Style != blue {
+		t.Errorf("Get %#v, want blue", c)
+	}
+
+	link := s.NewANSIStrings()
+	link.SetProfile(s.Profile{Hyperlink: true})
+	link.Str("golang").Link("https://go.dev/")
+	tb = s.NewTable()
+	tb.Border = s.BorderMarkdown
+	tb.BorderStyle.Red()
+	tb.Zebra.BgColorN(236)
+	tb.Columns = []s.Column{{MaxWidth: 5}, {Align: s.AlignCenter}}
+	tb.Header("a", "b").Row("truncated", "x|y").Row(&link, "\033[31mz\033[0m")
+	a = "| a                        |  b   |\n" +
+		"|--------------------------|:----:|\n" +
+		"| trun…                    | x\\|y |\n" +
+		"| [gola…](https://go.dev/) |  z   |"
+	if tb.String() != a {
+		t.Errorf("Get %#v, want %#v", tb.String(), a)
+	}
+
+	// wrapped cell and lines of cell are kept in one row
+	tb = s.NewTable()
+	tb.Border = s.BorderMarkdown
+	tb.Columns = []s.Column{{MaxWidth: 3, Wrap: true}}
+	tb.Row("long text", "a\nb")
+	a = "| long text | a<br>b |"
+	if tb.String() != a {
+		t.Errorf("Get %#v, want %#v", tb.String(), a)
+	}
+
+	tb = s.NewTable()
+	tb.Border = s.BorderASCII
+	tb.Zebra.BgColorN(236)
+	tb.Row("a", 1).Row("b", 2)
+	vt = s.NewVT(40, 0)
+	vt.WriteString(tb.String())
+	a = "+---+---+\n| a | 1 |\n| b | 2 |\n+---+---+"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	zebra := s.NewANSIStyle()
+	zebra.BgColorN(236)
+	for x := 2; x <= 4; x++ {
+		if c := vt.Cell(x, 3); c.Style != zebra {
+			t.Errorf("Get %#v, want zebra", c)
+		}
+	}
+	if c := vt.Cell(2, 2); c.Style != s.NewANSIStyle() {
+		t.Errorf("Get %#v, want no style", c)
+	}
+
+	// link has same id as String
+	link = s.NewANSIStrings()
+	link.SetProfile(s.Profile{Hyperlink: true})
+	link.Str("docs").Link("https://example.com/")
+	id := regexp.MustCompile(`id=[0-9a-f]+`)
+	tb = s.NewTable()
+	tb.Row(&link)
+	if a, str := id.FindString(link.String()), id.FindString(tb.String()); a == "" || str != a {
+		t.Errorf("Get %#v, want %#v", str, a)
+	}
+}
+
+func TestTableWide(t *testing.T) {
+	tb := s.NewTable()
+	tb.HeaderStyle = s.NewANSIStyle()
+	tb.Columns = []s.Column{{}, {MaxWidth: 5, Wrap: true}, {MaxWidth: 4}}
+	tb.Header("name", "x", "note").Row("日本語", "あいうえ", "漢字です")
+	a := "┌────────┬───────┬──────┐\n" +
+		"│ name   │ x     │ note │\n" +
+		"├────────┼───────┼──────┤\n" +
+		"│ 日本語 │ あい  │ 漢…  │\n" +
+		"│        │ うえ  │      │\n" +
+		"└────────┴───────┴──────┘"
+	if tb.String() != a {
+		t.Errorf("Get\n%s\nwant\n%s", tb.String(), a)
+	}
+}
@@ -0,0 +1,117 @@
+package ansistrings
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Theme is set of colors to render ANSIStrings as image
+type Theme struct {
+	Foreground color.RGBA
+	Background color.RGBA
+	// Palette is colors from Black(0) to White(15)
+	Palette [16]color.RGBA
+}
+
+// DefaultTheme is dark theme like xterm
+var DefaultTheme = Theme{
+	Foreground: color.RGBA{229, 229, 229, 255},
+	Background: color.RGBA{0, 0, 0, 255},
+	Palette: [16]color.RGBA{
+		{0, 0, 0, 255},
+		{205, 0, 0, 255},
+		{0, 205, 0, 255},
+		{205, 205, 0, 255},
+		{0, 0, 238, 255},
+		{205, 0, 205, 255},
+		{0, 205, 205, 255},
+		{229, 229, 229, 255},
+		{127, 127, 127, 255},
+		{255, 0, 0, 255},
+		{0, 255, 0, 255},
+		{255, 255, 0, 255},
+		{92, 92, 255, 255},
+		{255, 0, 255, 255},
+		{0, 255, 255, 255},
+		{255, 255, 255, 255},
+	},
+}
+
+// LightTheme is light theme
+var LightTheme = Theme{
+	Foreground: color.RGBA{56, 58, 66, 255},
+	Background: color.RGBA{250, 250, 250, 255},
+	Palette: [16]color.RGBA{
+		{0, 0, 0, 255},
+		{202, 18, 67, 255},
+		{80, 161, 79, 255},
+		{193, 132, 1, 255},
+		{64, 120, 242, 255},
+		{166, 38, 164, 255},
+		{1, 132, 188, 255},
+		{160, 161, 167, 255},
+		{105, 108, 119, 255},
+		{228, 86, 73, 255},
+		{80, 161, 79, 255},
+		{193, 132, 1, 255},
+		{64, 120, 242, 255},
+		{166, 38, 164, 255},
+		{1, 132, 188, 255},
+		{56, 58, 66, 255},
+	},
+}
+
+// ColorN returns color of given 256 colors number
+func (t Theme) ColorN(n int) color.RGBA {
+	levels := []uint8{0, 95, 135, 175, 215, 255}
+	switch {
+	case n < 0 || n > 255:
+		return t.Foreground
+	case n < 16:
+		return t.Palette[n]
+	case n < 232:
+		n -= 16
+		return color.RGBA{levels[n/36], levels[n/6%6], levels[n%6], 255}
+	default:
+		g := uint8(8 + (n-232)*10)
+		return color.RGBA{g, g, g, 255}
+	}
+}
+
+// colors returns foreground and background color of given style
+func (t Theme) colors(s ANSIStyle) (fg color.RGBA, bg color.RGBA) {
+	fg, bg = t.Foreground, t.Background
+	if s.color.isSet {
+		fg = t.ColorN(basicColorIndex(s.color.color))
+	} else if s.rgb.isSet {
+		fg = color.RGBA{uint8(s.rgb.r), uint8(s.rgb.g), uint8(s.rgb.b), 255}
+	} else if s.colorN.isSet {
+		fg = t.ColorN(s.colorN.color)
+	}
+	if s.bgColor.isSet {
+		bg = t.ColorN(basicColorIndex(s.bgColor.color))
+	} else if s.bgRgb.isSet {
+		bg = color.RGBA{uint8(s.bgRgb.r), uint8(s.bgRgb.g), uint8(s.bgRgb.b), 255}
+	} else if s.bgColorN.isSet {
+		bg = t.ColorN(s.bgColorN.color)
+	}
+	if s.withInverted {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// basicColorIndex converts color constant(e.g. Red) to palette index. -1 is returned for unknown color.
+func basicColorIndex(c int) int {
+	switch {
+	case c >= Black && c <= LightGray:
+		return c - Black
+	case c >= DarkGray && c <= White:
+		return c - DarkGray + 8
+	}
+	return -1
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
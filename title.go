@@ -0,0 +1,37 @@
+package ansistrings
+
+// Title sets window title and icon name(OSC 0)
+func (s *ANSIStrings) Title(title string) *ANSIStrings {
+	return s.setOSC("0;" + Sanitize(title, SanitizeStrip))
+}
+
+// IconName sets icon name(OSC 1)
+func (s *ANSIStrings) IconName(name string) *ANSIStrings {
+	return s.setOSC("1;" + Sanitize(name, SanitizeStrip))
+}
+
+// WindowTitle sets window title(OSC 2)
+func (s *ANSIStrings) WindowTitle(title string) *ANSIStrings {
+	return s.setOSC("2;" + Sanitize(title, SanitizeStrip))
+}
+
+// PushTitle saves window title and icon name to stack of terminal(XTWINOPS)
+func (s *ANSIStrings) PushTitle() *ANSIStrings {
+	s.setEscape("\033[22;0t")
+	s.CurrentStr().passthrough = true
+	return s
+}
+
+// PopTitle restores window title and icon name saved by PushTitle(XTWINOPS)
+func (s *ANSIStrings) PopTitle() *ANSIStrings {
+	s.setEscape("\033[23;0t")
+	s.CurrentStr().passthrough = true
+	return s
+}
+
+// setOSC adds OSC segment which is passed through terminal multiplexer
+func (s *ANSIStrings) setOSC(data string) *ANSIStrings {
+	s.setEscape("\033]" + data + "\a")
+	s.CurrentStr().passthrough = true
+	return s
+}
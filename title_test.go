@@ -0,0 +1,31 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestTitle(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.SetProfile(s.Profile{})
+	v.PushTitle().Title("job 1/3").IconName("i").WindowTitle("w\033]evil").PopTitle()
+	a := "\033[22;0t\033]0;job 1/3\a\033]1;i\a\033]2;w\a\033[23;0t"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	v = s.NewANSIStrings()
+	v.SetProfile(s.Profile{Multiplexer: s.MultiplexerTmux})
+	v.Title("t")
+	a = "\033Ptmux;\033\033]0;t\a\033\\"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+
+	v.SetProfile(s.Profile{Multiplexer: s.MultiplexerScreen})
+	a = "\033P\033]0;t\a\033\\"
+	if v.String() != a {
+		t.Errorf("Get %#v, want %#v", v.String(), a)
+	}
+}
@@ -0,0 +1,647 @@
+package ansistrings
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	vtGround = iota
+	vtEsc
+	vtCSI
+	// vtString is OSC, DCS, SOS, PM or APC string which ends with ST or BEL
+	vtString
+	vtStringEsc
+)
+
+// Cell is a character on VT screen.
+// right half of wide character is Cell whose Rune is 0.
+type Cell struct {
+	Rune  rune
+	Style ANSIStyle
+}
+
+// VT is in-memory virtual terminal which consumes ANSI escaped string.
+// It is useful to test output of Pos, Up, Down, Back, Forward and Clear.
+type VT struct {
+	width  int
+	height int
+	cells  [][]Cell
+	x      int
+	y      int
+	style  ANSIStyle
+	saved  struct{ x, y int }
+	region struct {
+		top    int
+		bottom int
+		isSet  bool
+	}
+	cursorHidden bool
+	// main is main screen while alternate screen is used
+	main   *VT
+	state  int
+	params []byte
+	rest   []byte
+}
+
+// NewVT returns new VT. height 0 means rows grow as needed.
+func NewVT(width int, height int) *VT {
+	if width < 1 || height < 0 {
+		panic("width should be greater than 0 and height should not be negative")
+	}
+	v := &VT{width: width, height: height}
+	v.ensureRow(height - 1)
+	return v
+}
+
+// Write consumes ANSI escaped bytes
+func (v *VT) Write(p []byte) (int, error) {
+	b := append(v.rest, p...)
+	v.rest = nil
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 && !utf8.FullRune(b) {
+			v.rest = append([]byte{}, b...)
+			break
+		}
+		b = b[size:]
+		v.consume(r)
+	}
+	return len(p), nil
+}
+
+// WriteString consumes ANSI escaped string
+func (v *VT) WriteString(s string) (int, error) {
+	return v.Write([]byte(s))
+}
+
+// Size returns width and height of screen
+func (v *VT) Size() (width int, height int) {
+	return v.width, len(v.cells)
+}
+
+// Cursor returns cursor position. top left is (1, 1) as same as Pos.
+func (v *VT) Cursor() (x int, y int) {
+	return v.x + 1, v.y + 1
+}
+
+// AltScreen returns whether alternate screen is used
+func (v *VT) AltScreen() bool {
+	return v.main != nil
+}
+
+// CursorHidden returns whether cursor is hidden
+func (v *VT) CursorHidden() bool {
+	return v.cursorHidden
+}
+
+// Cell returns Cell at given position. top left is (1, 1) as same as Pos.
+func (v *VT) Cell(x int, y int) Cell {
+	if x < 1 || x > v.width || y < 1 || y > len(v.cells) {
+		return Cell{Rune: ' '}
+	}
+	return v.cells[y-1][x-1]
+}
+
+// Line returns plain text of given row without trailing spaces
+func (v *VT) Line(y int) string {
+	if y < 1 || y > len(v.cells) {
+		return ""
+	}
+	rs := make([]rune, 0, v.width)
+	for _, c := range v.cells[y-1] {
+		if c.Rune != 0 {
+			rs = append(rs, c.Rune)
+		}
+	}
+	return strings.TrimRight(string(rs), " ")
+}
+
+// Text returns plain text of screen. trailing blank lines are trimmed.
+func (v *VT) Text() string {
+	lines := []string{}
+	for y := 1; y <= len(v.rows()); y++ {
+		lines = append(lines, v.Line(y))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (v *VT) consume(r rune) {
+	switch v.state {
+	case vtEsc:
+		v.state = vtGround
+		switch r {
+		case '[':
+			v.state = vtCSI
+			v.params = v.params[:0]
+		case ']', 'P', 'X', '^', '_':
+			v.state = vtString
+		case '\033':
+			v.state = vtEsc
+		case '7':
+			v.saved.x, v.saved.y = v.x, v.y
+		case '8':
+			v.moveTo(v.saved.x, v.saved.y)
+		case 'D':
+			v.lineFeed()
+		case 'E':
+			v.x = 0
+			v.lineFeed()
+		case 'M':
+			v.reverseLineFeed()
+		case 'c':
+			*v = *NewVT(v.width, v.height)
+		}
+		return
+	case vtCSI:
+		if r >= 0x40 && r <= 0x7e {
+			v.state = vtGround
+			v.csi(r, string(v.params))
+		} else {
+			v.params = append(v.params, string(r)...)
+		}
+		return
+	case vtString:
+		if r == '\a' {
+			v.state = vtGround
+		} else if r == '\033' {
+			v.state = vtStringEsc
+		}
+		return
+	case vtStringEsc:
+		// ESC other than ST cancels string and starts new escape sequence
+		if r == '\\' {
+			v.state = vtGround
+		} else {
+			v.state = vtEsc
+			v.consume(r)
+		}
+		return
+	}
+	switch r {
+	case '\033':
+		v.state = vtEsc
+	case '\r':
+		v.x = 0
+	case '\n', '\v', '\f':
+		v.x = 0
+		v.lineFeed()
+	case '\b':
+		if v.x > 0 {
+			v.x--
+		}
+	case '\t':
+		v.x = (v.x/8 + 1) * 8
+		if v.x >= v.width {
+			v.x = v.width - 1
+		}
+	default:
+		if r < 0x20 || r == 0x7f {
+			return
+		}
+		v.put(r)
+	}
+}
+
+func (v *VT) put(r rune) {
+	w := 1
+	if runeWidth(r) == 2 && v.width > 1 {
+		w = 2
+	}
+	if v.x+w > v.width {
+		v.x = 0
+		v.lineFeed()
+	}
+	v.ensureRow(v.y)
+	row := v.cells[v.y]
+	// overwriting a half of wide character clears the other half
+	if row[v.x].Rune == 0 && v.x > 0 {
+		row[v.x-1] = Cell{Rune: ' '}
+	}
+	if v.x+w < v.width && row[v.x+w].Rune == 0 {
+		row[v.x+w] = Cell{Rune: ' '}
+	}
+	row[v.x] = Cell{Rune: r, Style: v.style}
+	if w == 2 {
+		row[v.x+1] = Cell{Style: v.style}
+	}
+	v.x += w
+}
+
+func (v *VT) lineFeed() {
+	if v.region.isSet && v.y == v.region.bottom {
+		v.scrollUp(1)
+		return
+	}
+	v.y++
+	if v.y >= v.maxRows() {
+		v.y = v.maxRows() - 1
+		// cursor out of scroll region doesn't scroll
+		if !v.region.isSet {
+			v.scrollUp(1)
+		}
+	}
+	v.ensureRow(v.y)
+}
+
+func (v *VT) reverseLineFeed() {
+	if v.y == v.top() {
+		v.scrollDown(1)
+	} else if v.y > 0 {
+		v.y--
+	}
+}
+
+// top returns top row of scroll region
+func (v *VT) top() int {
+	if v.region.isSet {
+		return v.region.top
+	}
+	return 0
+}
+
+// bottom returns bottom row of scroll region
+func (v *VT) bottom() int {
+	if v.region.isSet {
+		return v.region.bottom
+	}
+	if v.height > 0 {
+		return v.height - 1
+	}
+	return len(v.cells) - 1
+}
+
+// scrollUpFrom scrolls rows from top(0 origin) to bottom of scroll region up
+func (v *VT) scrollUpFrom(top int, n int) {
+	bottom := v.bottom()
+	if top > bottom {
+		return
+	}
+	if n > bottom-top+1 {
+		n = bottom - top + 1
+	}
+	copy(v.cells[top:bottom+1], v.cells[top+n:bottom+1])
+	for y := bottom - n + 1; y <= bottom; y++ {
+		v.cells[y] = v.blankRow()
+	}
+}
+
+// scrollDownFrom scrolls rows from top(0 origin) to bottom of scroll region down
+func (v *VT) scrollDownFrom(top int, n int) {
+	bottom := v.bottom()
+	if top > bottom {
+		return
+	}
+	if n > bottom-top+1 {
+		n = bottom - top + 1
+	}
+	copy(v.cells[top+n:bottom+1], v.cells[top:bottom+1-n])
+	for y := top; y < top+n; y++ {
+		v.cells[y] = v.blankRow()
+	}
+}
+
+func (v *VT) scrollUp(n int) {
+	v.scrollUpFrom(v.top(), n)
+}
+
+func (v *VT) scrollDown(n int) {
+	v.scrollDownFrom(v.top(), n)
+}
+
+// setRegion sets scroll region(DECSTBM). top and bottom are 1 origin.
+func (v *VT) setRegion(top int, bottom int) {
+	height := v.height
+	if height == 0 {
+		height = len(v.cells)
+	}
+	if bottom == 0 || bottom > height {
+		bottom = height
+	}
+	if top < 1 {
+		top = 1
+	}
+	if top >= bottom {
+		return
+	}
+	v.region.top = top - 1
+	v.region.bottom = bottom - 1
+	v.region.isSet = top != 1 || bottom != height
+	v.moveTo(0, 0)
+}
+
+func (v *VT) blankRow() []Cell {
+	row := make([]Cell, v.width)
+	for i := range row {
+		row[i].Rune = ' '
+	}
+	return row
+}
+
+// maxRows returns number of rows which screen can have
+func (v *VT) maxRows() int {
+	if v.height > 0 {
+		return v.height
+	}
+	return maxHeight
+}
+
+func (v *VT) ensureRow(y int) {
+	for len(v.cells) <= y {
+		v.cells = append(v.cells, v.blankRow())
+	}
+}
+
+func (v *VT) moveTo(x int, y int) {
+	if x < 0 {
+		x = 0
+	} else if x >= v.width {
+		x = v.width - 1
+	}
+	if y < 0 {
+		y = 0
+	} else if y >= v.maxRows() {
+		y = v.maxRows() - 1
+	}
+	v.x = x
+	v.y = y
+	v.ensureRow(y)
+}
+
+// clampChars returns n limited to number of characters from cursor to end of line
+func (v *VT) clampChars(n int) int {
+	if n > v.width-v.x {
+		return v.width - v.x
+	}
+	return n
+}
+
+func (v *VT) erase(y int, from int, to int) {
+	v.ensureRow(y)
+	for x := from; x < to && x < v.width; x++ {
+		v.cells[y][x] = Cell{Rune: ' '}
+	}
+}
+
+func (v *VT) csi(final rune, params string) {
+	private := strings.HasPrefix(params, "?")
+	n := parseParams(params)
+	arg := func(i int, def int) int {
+		if i < len(n) && n[i] > 0 {
+			return n[i]
+		}
+		return def
+	}
+	if private {
+		if final == 'h' || final == 'l' {
+			v.setMode(n, final == 'h')
+		}
+		return
+	}
+	v.ensureRow(v.y)
+	switch final {
+	case 'A':
+		v.moveTo(v.x, v.y-arg(0, 1))
+	case 'B', 'e':
+		v.moveTo(v.x, v.y+arg(0, 1))
+	case 'C', 'a':
+		v.moveTo(v.x+arg(0, 1), v.y)
+	case 'D':
+		v.moveTo(v.x-arg(0, 1), v.y)
+	case 'E':
+		v.moveTo(0, v.y+arg(0, 1))
+	case 'F':
+		v.moveTo(0, v.y-arg(0, 1))
+	case 'G', '`':
+		v.moveTo(arg(0, 1)-1, v.y)
+	case 'd':
+		v.moveTo(v.x, arg(0, 1)-1)
+	case 'H', 'f':
+		v.moveTo(arg(1, 1)-1, arg(0, 1)-1)
+	case 'r':
+		v.setRegion(arg(0, 1), arg(1, 0))
+	case 's':
+		v.saved.x, v.saved.y = v.x, v.y
+	case 'u':
+		v.moveTo(v.saved.x, v.saved.y)
+	case 'J':
+		switch arg(0, 0) {
+		case 0:
+			v.erase(v.y, v.x, v.width)
+			for y := v.y + 1; y < len(v.cells); y++ {
+				v.erase(y, 0, v.width)
+			}
+		case 1:
+			for y := 0; y < v.y; y++ {
+				v.erase(y, 0, v.width)
+			}
+			v.erase(v.y, 0, v.x+1)
+		case 2:
+			if v.height == 0 {
+				v.cells = nil
+			}
+			for y := 0; y < len(v.cells); y++ {
+				v.erase(y, 0, v.width)
+			}
+			v.ensureRow(v.y)
+		case 3:
+			// clearing scrollback doesn't change screen and VT has no scrollback
+		}
+	case 'K':
+		switch arg(0, 0) {
+		case 0:
+			v.erase(v.y, v.x, v.width)
+		case 1:
+			v.erase(v.y, 0, v.x+1)
+		default:
+			v.erase(v.y, 0, v.width)
+		}
+	case 'X':
+		v.erase(v.y, v.x, v.x+arg(0, 1))
+	case 'L':
+		v.scrollDownFrom(v.y, arg(0, 1))
+	case 'M':
+		v.scrollUpFrom(v.y, arg(0, 1))
+	case '@':
+		row := v.cells[v.y]
+		k := v.clampChars(arg(0, 1))
+		copy(row[v.x+k:], row[v.x:])
+		v.erase(v.y, v.x, v.x+k)
+	case 'P':
+		row := v.cells[v.y]
+		k := v.clampChars(arg(0, 1))
+		copy(row[v.x:], row[v.x+k:])
+		v.erase(v.y, v.width-k, v.width)
+	case 'S':
+		v.scrollUp(arg(0, 1))
+	case 'T':
+		v.scrollDown(arg(0, 1))
+	case 'm':
+		v.style.applySGR(n)
+	}
+}
+
+func (v *VT) setMode(n []int, set bool) {
+	for _, m := range n {
+		switch m {
+		case 25:
+			v.cursorHidden = !set
+		case 47, 1047, 1049:
+			if set && v.main == nil {
+				main := *v
+				v.cells = nil
+				v.ensureRow(v.height - 1)
+				v.ensureRow(v.y)
+				v.main = &main
+			} else if !set && v.main != nil {
+				v.cells = v.main.cells
+				if m == 1049 {
+					v.x, v.y = v.main.x, v.main.y
+				}
+				v.main = nil
+			}
+		}
+	}
+}
+
+// maxParam is maximum value of CSI parameter to avoid overflow
+const maxParam = 65535
+
+// maxHeight is maximum number of rows when height is 0
+const maxHeight = 10000
+
+// parseParams parses CSI parameters like "38;5;1". Missing parameter is 0.
+func parseParams(params string) []int {
+	var n []int
+	cur := 0
+	seen := false
+	for i := 0; i < len(params); i++ {
+		c := params[i]
+		switch {
+		case c >= '0' && c <= '9':
+			cur = cur*10 + int(c-'0')
+			if cur > maxParam {
+				cur = maxParam
+			}
+			seen = true
+		case c == ';' || c == ':':
+			n = append(n, cur)
+			cur = 0
+			seen = false
+		}
+	}
+	if seen || len(n) > 0 {
+		n = append(n, cur)
+	}
+	return n
+}
+
+// applySGR applies SGR parameters to ANSIStyle
+func (s *ANSIStyle) applySGR(n []int) {
+	if len(n) == 0 {
+		*s = ANSIStyle{}
+		return
+	}
+	for i := 0; i < len(n); i++ {
+		p := n[i]
+		switch {
+		case p == 0:
+			*s = ANSIStyle{}
+		case p == 1:
+			s.withBold = true
+		case p == 2:
+			s.withFaint = true
+		case p == 3:
+			s.withItalic = true
+		case p == 4:
+			s.withUnderLine = true
+		case p == 5:
+			s.withBlink = true
+		case p == 6:
+			s.withRapidBlink = true
+		case p == 7:
+			s.withInverted = true
+		case p == 8:
+			s.withConceal = true
+		case p == 9:
+			s.withDelete = true
+		case p >= 11 && p <= 20:
+			s.font = p - 10
+		case p == 22:
+			s.withBold = false
+			s.withFaint = false
+		case p == 23:
+			s.withItalic = false
+		case p == 24:
+			s.withUnderLine = false
+		case p == 25:
+			s.withBlink = false
+			s.withRapidBlink = false
+		case p == 27:
+			s.withInverted = false
+		case p == 28:
+			s.withConceal = false
+		case p == 29:
+			s.withDelete = false
+		case (p >= 30 && p <= 37) || (p >= 90 && p <= 97):
+			s.Color(p)
+		case (p >= 40 && p <= 47) || (p >= 100 && p <= 107):
+			s.BgColor(p - 10)
+		case p == 39:
+			s.UnsetColor()
+		case p == 49:
+			s.UnsetBgColor()
+		case p == 38 || p == 48:
+			// out of range color is skipped
+			if i+2 < len(n) && n[i+1] == 5 {
+				if n[i+2] <= 255 && p == 38 {
+					s.ColorN(n[i+2])
+				} else if n[i+2] <= 255 {
+					s.BgColorN(n[i+2])
+				}
+				i += 2
+			} else if i+4 < len(n) && n[i+1] == 2 {
+				if n[i+2] <= 255 && n[i+3] <= 255 && n[i+4] <= 255 {
+					if p == 38 {
+						s.RGB(n[i+2], n[i+3], n[i+4])
+					} else {
+						s.BgRGB(n[i+2], n[i+3], n[i+4])
+					}
+				}
+				i += 4
+			} else {
+				return
+			}
+		}
+	}
+}
+
+// rows returns rows of screen. trailing blank rows are trimmed when height is 0.
+func (v *VT) rows() [][]Cell {
+	if v.height > 0 {
+		return v.cells
+	}
+	last := len(v.cells)
+	for ; last > 0; last-- {
+		if !isBlankRow(v.cells[last-1]) {
+			break
+		}
+	}
+	if last == 0 {
+		last = 1
+		v.ensureRow(0)
+	}
+	return v.cells[:last]
+}
+
+func isBlankRow(row []Cell) bool {
+	for _, c := range row {
+		if c.Rune != ' ' || c.Style != (ANSIStyle{}) {
+			return false
+		}
+	}
+	return true
+}
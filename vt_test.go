@@ -0,0 +1,151 @@
+package ansistrings_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestVT(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.Clear().Str("hello").Red().Down().Back(2).Str("world").Pos(2, 4).Str("x").Bold()
+	vt := s.NewVT(10, 5)
+	vt.WriteString(v.String())
+
+	a := "hello\n   world\n\n x"
+	if vt.Text() != a {
+		t.Errorf("Get %#v, want %#v", vt.Text(), a)
+	}
+	if x, y := vt.Cursor(); x != 3 || y != 4 {
+		t.Errorf("Get (%d, %d), want (3, 4)", x, y)
+	}
+
+	red := s.NewANSIStyle()
+	red.Red()
+	if c := vt.Cell(1, 1); c.Rune != 'h' || c.Style != red {
+		t.Errorf("Get %#v, want red h", c)
+	}
+	bold := s.NewANSIStyle()
+	bold.Bold()
+	if c := vt.Cell(2, 4); c.Rune != 'x' || c.Style != bold {
+		t.Errorf("Get %#v, want bold x", c)
+	}
+	if c := vt.Cell(4, 2); c.Style != (s.ANSIStyle{}) {
+		t.Errorf("Get %#v, want no style", c)
+	}
+}
+
+func TestVTSequences(t *testing.T) {
+	tests := []struct {
+		in string
+		a  string
+	}{
+		{"abc\rX", "Xbc"},
+		{"abcdef\033[3G\033[K", "ab"},
+		{"abcdef\033[3G\033[1K", "   def"},
+		{"abcdef\033[3G\033[2P", "abef"},
+		{"abcdef\033[3G\033[2@", "ab  cdef"},
+		{"abc\033[s\ndef\033[uX", "abcX\ndef"},
+		{"1\n2\n3\033[A\033[L", "1\n\n2\n3"},
+		{"1\n2\n3\033[A\033[M", "1\n3"},
+		{"1\n2\n3\n4\n5\n6", "3\n4\n5\n6"},
+		{"\033]0;title\007ok", "ok"},
+		{"hello\033[3J", "hello"},
+		{"hello\033[2J", ""},
+		{"\033[38;2;1;2;3mok\033[0m", "ok"},
+		{"\033P1$r0m\033\\ok", "ok"},
+		{"\033_apc\033\\\033^pm\007\033Xsos\033\\ok", "ok"},
+	}
+	for _, tt := range tests {
+		vt := s.NewVT(8, 4)
+		vt.WriteString(tt.in)
+		if vt.Text() != tt.a {
+			t.Errorf("%#v: Get %#v, want %#v", tt.in, vt.Text(), tt.a)
+		}
+	}
+
+	vt := s.NewVT(8, 0)
+	vt.WriteString("\033[?25l")
+	if !vt.CursorHidden() {
+		t.Error("cursor should be hidden")
+	}
+}
+
+func TestVTWide(t *testing.T) {
+	tests := []struct {
+		in string
+		a  string
+	}{
+		{"日本ab", "日本ab"},
+		{"abc日本語", "abc日\n本語"},
+		{"日本ab\033[2GX", " X本ab"},
+		{"日本ab\033[3GX", "日X ab"},
+		{"ab日本\033[2G語", "a語 本"},
+	}
+	for _, tt := range tests {
+		vt := s.NewVT(6, 0)
+		vt.WriteString(tt.in)
+		if vt.Text() != tt.a {
+			t.Errorf("%#v: Get %#v, want %#v", tt.in, vt.Text(), tt.a)
+		}
+	}
+
+	vt := s.NewVT(6, 1)
+	vt.WriteString("日本ac")
+	if c := vt.Cell(6, 1); c.Rune != 'c' {
+		t.Errorf("Get %#v, want c", c)
+	}
+	if c := vt.Cell(2, 1); c.Rune != 0 {
+		t.Errorf("Get %#v, want right half of wide character", c)
+	}
+}
+
+func TestVTMultiplexer(t *testing.T) {
+	for _, m := range []s.Multiplexer{s.MultiplexerTmux, s.MultiplexerScreen} {
+		v := s.NewANSIStrings()
+		v.SetProfile(s.Profile{Multiplexer: m})
+		v.Title("t").Str("ok")
+		vt := s.NewVT(8, 4)
+		vt.WriteString(v.String())
+		if vt.Text() != "ok" {
+			t.Errorf("%s: Get %#v, want %#v", m, vt.Text(), "ok")
+		}
+	}
+}
+
+func TestVTInvalidParams(t *testing.T) {
+	vt := s.NewVT(10, 3)
+	vt.WriteString("\033[38;5;9223372036854775808mab\033[0m\033[38;2;300;0;0;1mc")
+	if vt.Text() != "abc" {
+		t.Errorf("Get %#v, want %#v", vt.Text(), "abc")
+	}
+	bold := s.NewANSIStyle()
+	bold.Bold()
+	if c := vt.Cell(1, 1); c.Style != s.NewANSIStyle() {
+		t.Errorf("Get %#v, want no style", c)
+	}
+	if c := vt.Cell(3, 1); c.Style != bold {
+		t.Errorf("Get %#v, want bold", c)
+	}
+}
+
+func TestVTLargeParams(t *testing.T) {
+	done := make(chan bool)
+	go func() {
+		vt := s.NewVT(80, 24)
+		vt.WriteString("a\033[5000000S\033[5000000T\033[5000000L\033[5000000M\033[5000000@\033[5000000P")
+		vt = s.NewVT(80, 0)
+		vt.WriteString("\033[999999999;1Hb" + strings.Repeat("\033[999999999B\n", 100))
+		if _, h := vt.Size(); h > 10000 {
+			t.Errorf("Get height %d, want limited height", h)
+		}
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Error("too slow")
+	}
+}
@@ -0,0 +1,33 @@
+package ansistrings_test
+
+import (
+	"testing"
+
+	s "github.com/ktat/go-ansistrings"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	v := s.NewANSIStrings()
+	v.SetProfile(s.Profile{Hyperlink: true})
+	v.Str("abc").Red().Str("\nあいうえ").Link("https://example.com/")
+	if w := s.VisibleWidth(v.String()); w != 8 {
+		t.Errorf("Get %d, want 8", w)
+	}
+
+	tests := []struct {
+		in string
+		a  int
+	}{
+		{"cafe\u0301", 4},
+		{"👍 ok", 5},
+		{"ｱｲｳ", 3},
+		{"ＡＢ", 4},
+		{"한국어", 6},
+		{"✔ done", 6},
+	}
+	for _, tt := range tests {
+		if w := s.VisibleWidth(tt.in); w != tt.a {
+			t.Errorf("%#v: Get %d, want %d", tt.in, w, tt.a)
+		}
+	}
+}